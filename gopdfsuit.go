@@ -2,30 +2,86 @@
 package gopdfsuit
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"time"
 
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/barcode"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/builder"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/client"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/factory"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/reader"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/renderer"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/signer"
 )
 
 // Re-export domain types
 type (
-	Document      = domain.Document
-	Config        = domain.Config
-	Title         = domain.Title
-	Table         = domain.Table
-	Row           = domain.Row
-	Cell          = domain.Cell
-	FormField     = domain.FormField
-	Image         = domain.Image
-	Footer        = domain.Footer
-	FormFieldType = domain.FormFieldType
-	PageSize      = domain.PageSize
-	Alignment     = domain.Alignment
+	Document         = domain.Document
+	Config           = domain.Config
+	Title            = domain.Title
+	Table            = domain.Table
+	TableRef         = domain.TableRef
+	Block            = domain.Block
+	BlockType        = domain.BlockType
+	PageBreak        = domain.PageBreak
+	Spacer           = domain.Spacer
+	Group            = domain.Group
+	Paragraph        = domain.Paragraph
+	Heading          = domain.Heading
+	List             = domain.List
+	ListItem         = domain.ListItem
+	TableOfContents  = domain.TableOfContents
+	Row              = domain.Row
+	Cell             = domain.Cell
+	TextRun          = domain.TextRun
+	Bookmark         = domain.Bookmark
+	FormField        = domain.FormField
+	Image            = domain.Image
+	Shape            = domain.Shape
+	ShapeType        = domain.ShapeType
+	TextBox          = domain.TextBox
+	Metadata         = domain.Metadata
+	Header           = domain.Header
+	Footer           = domain.Footer
+	PageSlots        = domain.PageSlots
+	PageScope        = domain.PageScope
+	FormFieldType    = domain.FormFieldType
+	PageSize         = domain.PageSize
+	Alignment        = domain.Alignment
+	Style            = domain.Style
+	Borders          = domain.Borders
+	Color            = domain.Color
+	ValidationIssue  = domain.ValidationIssue
+	ValidationErrors = domain.ValidationErrors
+	HTMLRequest      = domain.HTMLDocument
+	HTMLMargins      = domain.HTMLMargins
+	Orientation      = domain.Orientation
+	ImageFormat      = domain.ImageFormat
+	Length           = domain.Length
+	Margins          = domain.Margins
+	Security         = domain.Security
+	Secret           = domain.Secret
+	Permissions      = domain.Permissions
+	Encryption       = domain.Encryption
+	Signature        = domain.Signature
+	SignatureInfo    = domain.SignatureInfo
+)
+
+// Re-export barcode types
+type (
+	Barcode       = barcode.Barcode
+	Symbology     = barcode.Symbology
+	QRLevel       = domain.QRLevel
+	QROptions     = domain.QROptions
+	BarcodeOption = barcode.Option
 )
 
 // Re-export builder interfaces
@@ -34,8 +90,10 @@ type (
 	TableBuilder    = domain.TableBuilder
 	CellBuilder     = domain.CellBuilder
 	DocumentReader  = domain.DocumentReader
+	DocumentSender  = domain.DocumentSender
 	Logger          = domain.Logger
 	RetryPolicy     = domain.RetryPolicy
+	PDFSigner       = domain.PDFSigner
 )
 
 // Re-export factory types
@@ -43,27 +101,128 @@ type (
 	RadioOption    = factory.RadioOption
 	CheckboxOption = factory.CheckboxOption
 	DocumentType   = factory.DocumentType
+	FieldOption    = factory.FieldOption
+)
+
+// Body block type constants
+const (
+	BlockTable     = domain.BlockTable
+	BlockPageBreak = domain.BlockPageBreak
+	BlockSpacer    = domain.BlockSpacer
+	BlockGroup     = domain.BlockGroup
+	BlockParagraph = domain.BlockParagraph
+	BlockHeading   = domain.BlockHeading
+	BlockList      = domain.BlockList
+	BlockContents  = domain.BlockContents
 )
 
 // Form field type constants
 const (
-	FormFieldText     = domain.FormFieldText
-	FormFieldCheckbox = domain.FormFieldCheckbox
-	FormFieldRadio    = domain.FormFieldRadio
+	FormFieldText      = domain.FormFieldText
+	FormFieldCheckbox  = domain.FormFieldCheckbox
+	FormFieldRadio     = domain.FormFieldRadio
+	FormFieldDropdown  = domain.FormFieldDropdown
+	FormFieldListbox   = domain.FormFieldListbox
+	FormFieldMultiline = domain.FormFieldMultiline
+	FormFieldDate      = domain.FormFieldDate
+	FormFieldSignature = domain.FormFieldSignature
 )
 
+// DefaultDateFormat is the date mask used by date fields without a format.
+const DefaultDateFormat = domain.DefaultDateFormat
+
 // Page size constants
 const (
 	PageSizeA4     = domain.PageSizeA4
 	PageSizeLetter = domain.PageSizeLetter
 	PageSizeLegal  = domain.PageSizeLegal
+	PageSizeCustom = domain.PageSizeCustom
+)
+
+// Header and footer token constants
+const (
+	TokenPage  = domain.TokenPage
+	TokenPages = domain.TokenPages
+	TokenDate  = domain.TokenDate
+	TokenTitle = domain.TokenTitle
+)
+
+// Page scope constants for headers and footers
+const (
+	ShowAllPages        = domain.ShowAllPages
+	ShowFirstPageOnly   = domain.ShowFirstPageOnly
+	ShowExceptFirstPage = domain.ShowExceptFirstPage
+)
+
+// Shape type constants
+const (
+	ShapeLine      = domain.ShapeLine
+	ShapeRect      = domain.ShapeRect
+	ShapeRoundRect = domain.ShapeRoundRect
+	ShapeEllipse   = domain.ShapeEllipse
+)
+
+// MaxImageSize is the largest image ImageFromURL downloads, in bytes.
+const MaxImageSize = builder.MaxImageSize
+
+// AllPages is the page of shapes and text boxes drawn on every page.
+const AllPages = domain.AllPages
+
+// Barcode symbology constants
+const (
+	Code128 = barcode.Code128
+	EAN13   = barcode.EAN13
+	QRCode  = barcode.QR
+)
+
+// QR code error correction levels
+const (
+	QRLevelL = domain.QRLevelL
+	QRLevelM = domain.QRLevelM
+	QRLevelQ = domain.QRLevelQ
+	QRLevelH = domain.QRLevelH
+)
+
+// Length unit constants
+const (
+	Point      = domain.Point
+	Inch       = domain.Inch
+	Centimeter = domain.Centimeter
+	Millimeter = domain.Millimeter
 )
 
 // Alignment constants
 const (
-	AlignLeft   = domain.AlignLeft
-	AlignCenter = domain.AlignCenter
-	AlignRight  = domain.AlignRight
+	AlignLeft    = domain.AlignLeft
+	AlignCenter  = domain.AlignCenter
+	AlignRight   = domain.AlignRight
+	AlignJustify = domain.AlignJustify
+)
+
+// Orientation constants
+const (
+	OrientationPortrait  = domain.OrientationPortrait
+	OrientationLandscape = domain.OrientationLandscape
+)
+
+// Image format constants
+const (
+	ImageFormatPNG  = domain.ImageFormatPNG
+	ImageFormatJPEG = domain.ImageFormatJPEG
+	ImageFormatSVG  = domain.ImageFormatSVG
+)
+
+// Encryption algorithm constants
+const (
+	EncryptionAES128 = domain.EncryptionAES128
+	EncryptionAES256 = domain.EncryptionAES256
+)
+
+// Image MIME type constants
+const (
+	MimeTypePNG  = domain.MimeTypePNG
+	MimeTypeJPEG = domain.MimeTypeJPEG
+	MimeTypeGIF  = domain.MimeTypeGIF
 )
 
 // Document type constants
@@ -74,10 +233,14 @@ const (
 	DocumentTypeCustom  = factory.DocumentTypeCustom
 )
 
+// DryRunDirEnv is the environment variable that enables dry-run mode.
+const DryRunDirEnv = client.DryRunDirEnv
+
 // Error variables
 var (
 	ErrDocumentNil        = domain.ErrDocumentNil
 	ErrInvalidConfig      = domain.ErrInvalidConfig
+	ErrInvalidDocument    = domain.ErrInvalidDocument
 	ErrEmptyDocument      = domain.ErrEmptyDocument
 	ErrFileNotFound       = domain.ErrFileNotFound
 	ErrInvalidJSON        = domain.ErrInvalidJSON
@@ -87,19 +250,36 @@ var (
 	ErrInvalidResponse    = domain.ErrInvalidResponse
 	ErrUnauthorized       = domain.ErrUnauthorized
 	ErrServerError        = domain.ErrServerError
+	ErrInvalidProps       = domain.ErrInvalidProps
+	ErrInvalidColor       = domain.ErrInvalidColor
+	ErrInvalidImage       = domain.ErrInvalidImage
+	ErrInvalidBarcode     = domain.ErrInvalidBarcode
+	ErrInvalidPDF         = domain.ErrInvalidPDF
+	ErrInvalidKey         = domain.ErrInvalidKey
+	ErrInvalidSignature   = domain.ErrInvalidSignature
+	ErrNoInputs           = domain.ErrNoInputs
+	ErrUnsupported        = domain.ErrUnsupported
 )
 
 // Client is the main entry point for the PDF client library.
 type Client struct {
 	httpClient *client.Client
 	pdfClient  *client.PDFClient
+	sender     domain.DocumentSender
 }
 
 type clientConfig struct {
-	timeout    time.Duration
-	endpoint   string
-	maxRetries int
-	headers    map[string]string
+	timeout       time.Duration
+	endpoint      string
+	mergeEndpoint string
+	fillEndpoint  string
+	htmlPDF       string
+	htmlImage     string
+	maxRetries    int
+	headers       map[string]string
+	dryRunDir     string
+	noValidate    bool
+	signer        domain.PDFSigner
 }
 
 // ClientOption is a functional option for configuring the Client.
@@ -115,6 +295,24 @@ func WithEndpoint(endpoint string) ClientOption {
 	return func(c *clientConfig) { c.endpoint = endpoint }
 }
 
+// WithMergeEndpoint sets the PDF merge endpoint.
+func WithMergeEndpoint(endpoint string) ClientOption {
+	return func(c *clientConfig) { c.mergeEndpoint = endpoint }
+}
+
+// WithFillEndpoint sets the form-fill endpoint.
+func WithFillEndpoint(endpoint string) ClientOption {
+	return func(c *clientConfig) { c.fillEndpoint = endpoint }
+}
+
+// WithHTMLEndpoints sets the HTML-to-PDF and HTML-to-image endpoints.
+func WithHTMLEndpoints(pdfEndpoint, imageEndpoint string) ClientOption {
+	return func(c *clientConfig) {
+		c.htmlPDF = pdfEndpoint
+		c.htmlImage = imageEndpoint
+	}
+}
+
 // WithMaxRetries sets the maximum number of retries.
 func WithMaxRetries(maxRetries int) ClientOption {
 	return func(c *clientConfig) { c.maxRetries = maxRetries }
@@ -130,13 +328,36 @@ func WithHeader(key, value string) ClientOption {
 	}
 }
 
+// WithDryRun writes the JSON payloads that would be posted to dir instead of
+// sending them, and returns a placeholder PDF. It overrides DryRunDirEnv.
+func WithDryRun(dir string) ClientOption {
+	return func(c *clientConfig) { c.dryRunDir = dir }
+}
+
+// WithoutValidation disables the Document.Validate check that Send runs
+// before posting a document.
+func WithoutValidation() ClientOption {
+	return func(c *clientConfig) { c.noValidate = true }
+}
+
+// WithSigner signs the PDF of every document whose configuration has a
+// signature. In dry-run mode nothing is signed.
+func WithSigner(signer PDFSigner) ClientOption {
+	return func(c *clientConfig) { c.signer = signer }
+}
+
 // NewClient creates a new PDF Client with the given base URL and options.
 func NewClient(baseURL string, opts ...ClientOption) *Client {
 	cfg := &clientConfig{
-		timeout:    30 * time.Second,
-		endpoint:   "/api/v1/generate/template-pdf",
-		maxRetries: 3,
-		headers:    make(map[string]string),
+		timeout:       30 * time.Second,
+		endpoint:      client.DefaultGenerateEndpoint,
+		mergeEndpoint: client.DefaultMergeEndpoint,
+		fillEndpoint:  client.DefaultFillEndpoint,
+		htmlPDF:       client.DefaultHTMLToPDFEndpoint,
+		htmlImage:     client.DefaultHTMLToImageEndpoint,
+		maxRetries:    3,
+		headers:       make(map[string]string),
+		dryRunDir:     os.Getenv(DryRunDirEnv),
 	}
 	for _, opt := range opts {
 		opt(cfg)
@@ -145,26 +366,148 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 	clientOpts := []client.Option{
 		client.WithTimeout(cfg.timeout),
 		client.WithMaxRetries(cfg.maxRetries),
+		client.WithDryRun(cfg.dryRunDir),
 	}
 	for k, v := range cfg.headers {
 		clientOpts = append(clientOpts, client.WithHeader(k, v))
 	}
 
 	httpClient := client.New(baseURL, clientOpts...)
+	pdfClient := client.NewPDFClient(httpClient, cfg.endpoint)
+	pdfClient.SetMergeEndpoint(cfg.mergeEndpoint)
+	pdfClient.SetFillEndpoint(cfg.fillEndpoint)
+	pdfClient.SetHTMLEndpoints(cfg.htmlPDF, cfg.htmlImage)
+	pdfClient.SetValidation(!cfg.noValidate)
+	var sender domain.DocumentSender = pdfClient
+	if cfg.signer != nil && cfg.dryRunDir == "" {
+		sender = client.NewSigningSender(sender, cfg.signer)
+	}
 	return &Client{
 		httpClient: httpClient,
-		pdfClient:  client.NewPDFClient(httpClient, cfg.endpoint),
+		pdfClient:  pdfClient,
+		sender:     sender,
+	}
+}
+
+type localConfig struct {
+	noValidate bool
+	signer     domain.PDFSigner
+}
+
+// LocalOption is a functional option for configuring a Client created with
+// NewLocalClient.
+type LocalOption func(*localConfig)
+
+// WithoutLocalValidation disables the Document.Validate check that Send runs
+// before rendering a document.
+func WithoutLocalValidation() LocalOption {
+	return func(c *localConfig) { c.noValidate = true }
+}
+
+// WithLocalSigner signs the PDF of every document whose configuration has a
+// signature.
+func WithLocalSigner(signer PDFSigner) LocalOption {
+	return func(c *localConfig) { c.signer = signer }
+}
+
+// NewLocalClient creates a Client that renders documents in-process with the
+// pure Go renderer instead of posting them to a PDF service. Like NewClient,
+// it validates documents before rendering them.
+func NewLocalClient(opts ...LocalOption) *Client {
+	cfg := &localConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	r := renderer.New()
+	r.SetValidation(!cfg.noValidate)
+	var sender domain.DocumentSender = r
+	if cfg.signer != nil {
+		sender = client.NewSigningSender(sender, cfg.signer)
+	}
+	return &Client{
+		sender: sender,
 	}
 }
 
-// Send sends a document to the PDF service.
+// Send sends a document to the PDF service, or renders it locally for a
+// client created with NewLocalClient.
 func (c *Client) Send(ctx context.Context, doc *Document) ([]byte, error) {
-	return c.pdfClient.Send(ctx, doc)
+	return c.sender.Send(ctx, doc)
 }
 
 // SendAndSave sends a document and saves the PDF to a file.
 func (c *Client) SendAndSave(ctx context.Context, doc *Document, outputPath string) error {
-	return c.pdfClient.SendAndSave(ctx, doc, outputPath)
+	response, err := c.Send(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, response, 0644)
+}
+
+// Merge merges PDFs into a single document using the service's merge endpoint.
+// Inputs may be PDF bytes wrapped in a bytes.Reader, open files, or the output
+// of Send; they are merged in the order given.
+func (c *Client) Merge(ctx context.Context, inputs ...io.Reader) ([]byte, error) {
+	if c.pdfClient == nil {
+		return nil, fmt.Errorf("%w: merge requires a PDF service", ErrUnsupported)
+	}
+	return c.pdfClient.Merge(ctx, inputs...)
+}
+
+// MergeFiles merges the PDF files at the given paths. The files are read
+// up front and closed before the request is sent.
+func (c *Client) MergeFiles(ctx context.Context, paths ...string) ([]byte, error) {
+	inputs := make([]io.Reader, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+			}
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		inputs = append(inputs, namedReader{Reader: bytes.NewReader(data), name: path})
+	}
+	return c.Merge(ctx, inputs...)
+}
+
+// namedReader is the content of a file that keeps the file name, which
+// Merge sends as the name of the uploaded part.
+type namedReader struct {
+	*bytes.Reader
+	name string
+}
+
+// Name returns the path of the file.
+func (r namedReader) Name() string {
+	return r.name
+}
+
+// Fill fills the form fields of an existing PDF using the service's XFDF
+// fill endpoint and returns the filled PDF. Each field takes one value, or
+// several for a multi-select list box. Use FieldValues to derive the values
+// from FormField definitions.
+func (c *Client) Fill(ctx context.Context, pdf io.Reader, values map[string][]string) ([]byte, error) {
+	if c.pdfClient == nil {
+		return nil, fmt.Errorf("%w: fill requires a PDF service", ErrUnsupported)
+	}
+	return c.pdfClient.Fill(ctx, pdf, values)
+}
+
+// HTMLToPDF converts inline HTML or a URL to PDF using the service.
+func (c *Client) HTMLToPDF(ctx context.Context, req HTMLRequest) ([]byte, error) {
+	if c.pdfClient == nil {
+		return nil, fmt.Errorf("%w: HTML conversion requires a PDF service", ErrUnsupported)
+	}
+	return c.pdfClient.HTMLToPDF(ctx, req)
+}
+
+// HTMLToImage converts inline HTML or a URL to an image using the service.
+func (c *Client) HTMLToImage(ctx context.Context, req HTMLRequest, format ImageFormat) ([]byte, error) {
+	if c.pdfClient == nil {
+		return nil, fmt.Errorf("%w: HTML conversion requires a PDF service", ErrUnsupported)
+	}
+	return c.pdfClient.HTMLToImage(ctx, req, format)
 }
 
 // ReadFromFile reads a document from a JSON file.
@@ -177,6 +520,24 @@ func (c *Client) ReadFromBytes(ctx context.Context, data []byte) (*Document, err
 	return reader.NewJSONBytesReader(data).Read(ctx)
 }
 
+// WriteToFile writes a document to a JSON file that ReadFromFile reads back.
+func (c *Client) WriteToFile(ctx context.Context, doc *Document, filePath string) error {
+	return reader.NewJSONFileWriter(filePath).Write(ctx, doc)
+}
+
+// WriteToBytes encodes a document as indented JSON.
+func (c *Client) WriteToBytes(doc *Document) ([]byte, error) {
+	return reader.MarshalDocument(doc)
+}
+
+// FieldValues maps form fields to the values accepted by Fill.
+// Checkboxes map to their value (or "Yes") when checked and "Off" otherwise;
+// radio buttons map their group name to the value of the checked option;
+// multi-select list boxes map to each of their selections.
+func FieldValues(fields ...FormField) map[string][]string {
+	return domain.FieldValues(fields...)
+}
+
 // NewDocumentBuilder creates a new DocumentBuilder.
 func NewDocumentBuilder() DocumentBuilder {
 	return builder.NewDocumentBuilder()
@@ -212,6 +573,212 @@ func NewCell(props, text string) Cell {
 	return builder.Cell(props, text)
 }
 
+// NewStyledCell creates a simple cell from a typed Style and text.
+func NewStyledCell(style Style, text string) Cell {
+	return builder.StyledCell(style, text)
+}
+
+// NewRichCell creates a cell from inline markup: **bold**, _italic_ and
+// [text](url) links. Its plain text is kept in Text for services that do
+// not support runs.
+func NewRichCell(props, markup string) Cell {
+	return builder.RichCell(props, markup)
+}
+
+// ParseMarkup parses inline markup into text runs styled from base.
+func ParseMarkup(base Style, markup string) []TextRun {
+	return builder.Markup(base, markup)
+}
+
+// NewSpanCell creates a cell that spans colspan columns and rowspan rows.
+func NewSpanCell(props, text string, colspan, rowspan int) Cell {
+	return builder.SpanCell(props, text, colspan, rowspan)
+}
+
+// NewBulletList creates a bulleted list of plain items.
+func NewBulletList(items ...string) List {
+	return builder.BulletList(items...)
+}
+
+// NewNumberedList creates a numbered list of plain items.
+func NewNumberedList(items ...string) List {
+	return builder.NumberedList(items...)
+}
+
+// EncodeBarcode encodes data in a barcode symbology, for rendering with
+// PNG or NewBarcodeShapes.
+func EncodeBarcode(symbology Symbology, data string, opts ...BarcodeOption) (*Barcode, error) {
+	b, err := barcode.Encode(symbology, data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBarcode, err)
+	}
+	return b, nil
+}
+
+// NewBarcodeImage encodes data as an inline PNG image sized one point per
+// module, quiet zone included.
+func NewBarcodeImage(symbology Symbology, data string, opts ...BarcodeOption) (Image, error) {
+	return builder.BarcodeImage(symbology, data, opts...)
+}
+
+// NewBarcodeCell creates a cell showing data encoded as a barcode, scaled to
+// fit the cell.
+func NewBarcodeCell(props string, symbology Symbology, data string, opts ...BarcodeOption) (Cell, error) {
+	return builder.BarcodeCell(props, symbology, data, opts...)
+}
+
+// NewBarcodeShapes draws a barcode as vector rectangles with its top-left
+// corner at (x, y), moduleSize per module.
+func NewBarcodeShapes(b *Barcode, x, y, moduleSize Length) []Shape {
+	return builder.BarcodeShapes(b, x, y, moduleSize)
+}
+
+// WithQRLevel sets the error correction level of QR codes.
+func WithQRLevel(level QRLevel) BarcodeOption {
+	return builder.QRLevel(level)
+}
+
+// WithQuietZone sets the blank margin around a barcode in modules.
+func WithQuietZone(modules int) BarcodeOption {
+	return barcode.WithQuietZone(modules)
+}
+
+// WithBarHeight sets the bar height of linear barcodes in modules.
+func WithBarHeight(modules int) BarcodeOption {
+	return barcode.WithBarHeight(modules)
+}
+
+// NewLine creates a line from (x1, y1) to (x2, y2), measured from the
+// top-left corner of the page.
+func NewLine(x1, y1, x2, y2 Length) Shape {
+	return builder.Line(x1, y1, x2, y2)
+}
+
+// NewRect creates a rectangle whose top-left corner is at (x, y).
+func NewRect(x, y, width, height Length) Shape {
+	return builder.Rect(x, y, width, height)
+}
+
+// NewRoundRect creates a rectangle with corners rounded by radius.
+func NewRoundRect(x, y, width, height, radius Length) Shape {
+	return builder.RoundRect(x, y, width, height, radius)
+}
+
+// NewEllipse creates an ellipse inscribed in the rectangle whose top-left
+// corner is at (x, y).
+func NewEllipse(x, y, width, height Length) Shape {
+	return builder.Ellipse(x, y, width, height)
+}
+
+// NewTextBox creates a text box at (x, y) sized to fit its text.
+func NewTextBox(x, y Length, props, text string) TextBox {
+	return builder.TextAt(x, y, props, text)
+}
+
+// NewImageCell creates a cell showing an image.
+func NewImageCell(props string, image Image) Cell {
+	return builder.ImageCell(props, image)
+}
+
+// NewImage creates an inline image from PNG, JPEG or GIF bytes, detecting the
+// format and setting Width and Height to the pixel dimensions.
+func NewImage(data []byte) (Image, error) {
+	return domain.NewImage(data)
+}
+
+// ImageFromFile reads a PNG, JPEG or GIF file into an inline image.
+func ImageFromFile(path string) (Image, error) {
+	return builder.ImageFromFile(path)
+}
+
+// ImageFromReader reads PNG, JPEG or GIF data into an inline image.
+func ImageFromReader(r io.Reader) (Image, error) {
+	return builder.ImageFromReader(r)
+}
+
+// ImageFromURL downloads an image of at most MaxImageSize bytes, or decodes a
+// data URI, into an inline image. A nil client uses one with a 30 second
+// timeout.
+func ImageFromURL(ctx context.Context, client *http.Client, url string) (Image, error) {
+	return builder.ImageFromURL(ctx, client, url)
+}
+
+// ImageFromDataURI decodes a "data:image/png;base64,..." URI into an inline image.
+func ImageFromDataURI(uri string) (Image, error) {
+	return domain.ParseDataURI(uri)
+}
+
+// Pt returns a length of v points.
+func Pt(v float64) Length { return domain.Pt(v) }
+
+// Mm returns a length of v millimeters.
+func Mm(v float64) Length { return domain.Mm(v) }
+
+// Cm returns a length of v centimeters.
+func Cm(v float64) Length { return domain.Cm(v) }
+
+// In returns a length of v inches.
+func In(v float64) Length { return domain.In(v) }
+
+// ParseLength parses a length such as "25mm", "0.5in" or "12pt".
+func ParseLength(s string) (Length, error) {
+	return domain.ParseLength(s)
+}
+
+// AllPermissions returns a permission set allowing everything.
+func AllPermissions() Permissions {
+	return domain.AllPermissions()
+}
+
+// Signer signs PDFs with a private key and certificate. Pass it to
+// WithSigner or WithLocalSigner, or call Sign directly on any PDF.
+type Signer = signer.Signer
+
+// NewSigner creates a Signer from an RSA or ECDSA private key, its
+// certificate and optional intermediate certificates.
+func NewSigner(key crypto.PrivateKey, cert *x509.Certificate, chain ...*x509.Certificate) (*Signer, error) {
+	return signer.New(key, cert, chain...)
+}
+
+// SignerFromPKCS12 creates a Signer from a .p12 or .pfx file.
+func SignerFromPKCS12(data []byte, password string) (*Signer, error) {
+	return signer.FromPKCS12(data, password)
+}
+
+// SignerFromPEM creates a Signer from PEM certificates and a private key,
+// which may be encrypted PKCS#8.
+func SignerFromPEM(certPEM, keyPEM []byte, password string) (*Signer, error) {
+	return signer.FromPEM(certPEM, keyPEM, password)
+}
+
+// VerifySignature verifies the signatures of a PDF and describes them. It
+// does not check the certificates against trusted roots.
+func VerifySignature(pdf []byte) ([]SignatureInfo, error) {
+	return signer.Verify(pdf)
+}
+
+// UniformMargins returns margins of the same length on every side.
+func UniformMargins(l Length) Margins {
+	return domain.UniformMargins(l)
+}
+
+// ParseProps parses a props string such as "font1:9:100:left:1:1:1:1" into a Style.
+func ParseProps(props string) (Style, error) {
+	return domain.ParseProps(props)
+}
+
+// AnchorLink returns the link target of a named anchor, such as "#terms",
+// for Cell.Link, Paragraph.Link and markup links.
+func AnchorLink(anchor string) string {
+	return domain.AnchorLink(anchor)
+}
+
+// ParseColor converts a hex ("#F0F0F0", "#EEE"), RGB ("rgb(240, 240, 240)",
+// "240,240,240") or named ("lightgray") color to the "#RRGGBB" wire format.
+func ParseColor(s string) (Color, error) {
+	return domain.ParseColor(s)
+}
+
 // NewTextFieldCell creates a cell with a text form field.
 func NewTextFieldCell(props, text, name, value string) Cell {
 	return builder.TextFieldCell(props, text, name, value)
@@ -227,6 +794,33 @@ func NewRadioCell(props, name, value, groupName string, checked bool) Cell {
 	return builder.RadioCell(props, name, value, groupName, checked)
 }
 
+// NewDropdownCell creates a cell with a combo box.
+func NewDropdownCell(props, name string, options []string, value string) Cell {
+	return builder.DropdownCell(props, name, options, value)
+}
+
+// NewListboxCell creates a cell with a list box. More than one selection
+// makes it multi-select.
+func NewListboxCell(props, name string, options []string, selected ...string) Cell {
+	return builder.ListboxCell(props, name, options, selected...)
+}
+
+// NewMultilineCell creates a cell with a multiline text area.
+func NewMultilineCell(props, name, value string) Cell {
+	return builder.MultilineCell(props, name, value)
+}
+
+// NewDateCell creates a cell with a date field using a format mask such as
+// "dd/mm/yyyy". An empty format uses DefaultDateFormat.
+func NewDateCell(props, name, value, format string) Cell {
+	return builder.DateCell(props, name, value, format)
+}
+
+// NewSignatureCell creates a cell with an empty signature field.
+func NewSignatureCell(props, name string) Cell {
+	return builder.SignatureCell(props, name)
+}
+
 // NewJSONFileReader creates a new JSON file reader.
 func NewJSONFileReader(filePath string) *reader.JSONFileReader {
 	return reader.NewJSONFileReader(filePath)
@@ -237,6 +831,27 @@ func NewJSONBytesReader(data []byte) *reader.JSONBytesReader {
 	return reader.NewJSONBytesReader(data)
 }
 
+// Required marks a FormBuilder field as required.
+func Required() FieldOption { return factory.Required() }
+
+// ReadOnly makes a FormBuilder field read-only.
+func ReadOnly() FieldOption { return factory.ReadOnly() }
+
+// MaxLength limits the characters a FormBuilder field accepts.
+func MaxLength(n int) FieldOption { return factory.MaxLength(n) }
+
+// Tooltip sets the tooltip of a FormBuilder field.
+func Tooltip(text string) FieldOption { return factory.Tooltip(text) }
+
+// TabIndex sets the tab order position of a FormBuilder field.
+func TabIndex(index int) FieldOption { return factory.TabIndex(index) }
+
+// DefaultValue sets the value a FormBuilder field resets to.
+func DefaultValue(value string) FieldOption { return factory.DefaultValue(value) }
+
+// Pattern sets a regular expression a FormBuilder field value must match.
+func Pattern(pattern string) FieldOption { return factory.Pattern(pattern) }
+
 // NewDocumentFactory creates a new document factory.
 func NewDocumentFactory() *factory.DocumentFactory {
 	return factory.NewDocumentFactory()
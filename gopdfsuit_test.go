@@ -0,0 +1,258 @@
+package gopdfsuit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pdf "github.com/chinmay-sawant/gopdfsuit-client"
+)
+
+func TestLocalClientSignature(t *testing.T) {
+	p12, err := os.ReadFile(filepath.Join("internal", "signer", "testdata", "rsa-aes.p12"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := pdf.SignerFromPKCS12(p12, "secret")
+	if err != nil {
+		t.Fatalf("SignerFromPKCS12: %v", err)
+	}
+	props := "font1:10:000:left:1:1:1:1"
+
+	tests := []struct {
+		name      string
+		signature *pdf.Signature
+		wantField string
+	}{
+		{"no signature requested", nil, ""},
+		{"invisible", &pdf.Signature{Reason: "Approval", Location: "Berlin"}, "Signature1"},
+		{"in field", &pdf.Signature{Field: "approval", Reason: "Approval"}, "approval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := pdf.NewDocumentBuilder().
+				AddTable(pdf.NewTableBuilder().
+					WithColumns(2, []float64{1, 1}).
+					AddRowWithHeight(60, pdf.NewCell(props, "Approved by"), pdf.NewSignatureCell(props, "approval")).
+					Build()).
+				Build()
+			doc.Config.Signature = tt.signature
+
+			out, err := pdf.NewLocalClient(pdf.WithLocalSigner(signer)).Send(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			infos, err := pdf.VerifySignature(out)
+			if tt.signature == nil {
+				if !errors.Is(err, pdf.ErrInvalidSignature) {
+					t.Fatalf("VerifySignature error = %v, want %v", err, pdf.ErrInvalidSignature)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifySignature: %v", err)
+			}
+			if len(infos) != 1 || infos[0].Field != tt.wantField {
+				t.Fatalf("signatures = %+v, want one in field %q", infos, tt.wantField)
+			}
+			if infos[0].Reason != tt.signature.Reason || infos[0].Location != tt.signature.Location {
+				t.Errorf("signature = %+v, want the details of %+v", infos[0], *tt.signature)
+			}
+			if !infos[0].CoversWholeFile {
+				t.Error("CoversWholeFile = false for an unmodified file")
+			}
+		})
+	}
+}
+
+func TestDocumentBuilderTables(t *testing.T) {
+	props := "font1:10:000:left:1:1:1:1"
+	table := func(text string) pdf.Table {
+		return pdf.NewTableBuilder().WithColumns(1, []float64{1}).AddRow(pdf.NewCell(props, text)).Build()
+	}
+
+	tests := []struct {
+		name         string
+		build        func(pdf.DocumentBuilder) pdf.DocumentBuilder
+		wantTables   []string
+		wantElements string // empty when there is no "elements" member
+	}{
+		{
+			name: "tables only",
+			build: func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+				return b.AddTable(table("A")).AddTable(table("B"))
+			},
+			wantTables:   []string{"A", "B"},
+			wantElements: `[{"type":"table","index":0},{"type":"table","index":1}]`,
+		},
+		{
+			name: "page break between tables",
+			build: func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+				return b.AddTable(table("A")).AddPageBreak().AddTable(table("B"))
+			},
+			wantTables:   []string{"A", "B"},
+			wantElements: `[{"type":"table","index":0},{"type":"pagebreak"},{"type":"table","index":1}]`,
+		},
+		{
+			name: "group",
+			build: func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+				c := table("C")
+				return b.AddTable(table("A")).
+					AddGroup(table("B"), pdf.Spacer{Height: pdf.Pt(6)}, &c).
+					AddTable(table("D"))
+			},
+			wantTables:   []string{"A", "B", "C", "D"},
+			wantElements: `[{"type":"table","index":0},{"type":"group","elements":[{"type":"table","index":1},{"type":"spacer","height":6},{"type":"table","index":2}]},{"type":"table","index":3}]`,
+		},
+		{
+			name:       "empty",
+			build:      func(b pdf.DocumentBuilder) pdf.DocumentBuilder { return b },
+			wantTables: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := tt.build(pdf.NewDocumentBuilder()).Build()
+			var texts []string
+			for _, table := range doc.Tables {
+				texts = append(texts, table.Rows[0].Cells[0].Text)
+			}
+			if fmt.Sprint(texts) != fmt.Sprint(tt.wantTables) {
+				t.Errorf("doc.Tables = %v, want %v", texts, tt.wantTables)
+			}
+
+			data, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var raw struct {
+				Tables   []json.RawMessage `json:"table"`
+				Elements json.RawMessage   `json:"elements"`
+			}
+			if err := json.Unmarshal(data, &raw); err != nil {
+				t.Fatal(err)
+			}
+			if raw.Tables == nil || len(raw.Tables) != len(tt.wantTables) {
+				t.Errorf("table holds %d tables, want %d", len(raw.Tables), len(tt.wantTables))
+			}
+			if got := string(raw.Elements); got != tt.wantElements {
+				t.Errorf("elements\n got %s\nwant %s", got, tt.wantElements)
+			}
+			if err := doc.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestCellBuilderFieldAttributes(t *testing.T) {
+	props := "font1:10:000:left:1:1:1:1"
+	tests := []struct {
+		name    string
+		build   func(pdf.CellBuilder) pdf.CellBuilder
+		want    pdf.FormField
+		wantErr bool
+	}{
+		{
+			name: "attributes after the field",
+			build: func(b pdf.CellBuilder) pdf.CellBuilder {
+				return b.WithTextField("email", "a@b.c").WithRequired().WithMaxLength(64).WithTabIndex(3)
+			},
+			want: pdf.FormField{Type: pdf.FormFieldText, Name: "email", Value: "a@b.c", Required: true, MaxLength: 64, TabIndex: 3},
+		},
+		{
+			name: "attributes before the field",
+			build: func(b pdf.CellBuilder) pdf.CellBuilder {
+				return b.WithRequired().WithReadOnly().WithMaxLength(64).WithTooltip("Work email").
+					WithTabIndex(3).WithDefaultValue("x@y.z").WithPattern(`[^@]+@[^@]+`).
+					WithTextField("email", "a@b.c")
+			},
+			want: pdf.FormField{Type: pdf.FormFieldText, Name: "email", Value: "a@b.c", Required: true, ReadOnly: true,
+				MaxLength: 64, Tooltip: "Work email", TabIndex: 3, DefaultValue: "x@y.z", Pattern: `[^@]+@[^@]+`},
+		},
+		{
+			name: "field replaced",
+			build: func(b pdf.CellBuilder) pdf.CellBuilder {
+				return b.WithTextField("old", "").WithRequired().WithMultiline("notes", "")
+			},
+			want: pdf.FormField{Type: pdf.FormFieldMultiline, Name: "notes", Required: true},
+		},
+		{
+			name: "value longer than max length",
+			build: func(b pdf.CellBuilder) pdf.CellBuilder {
+				return b.WithMaxLength(3).WithTextField("code", "ABCD")
+			},
+			want:    pdf.FormField{Type: pdf.FormFieldText, Name: "code", Value: "ABCD", MaxLength: 3},
+			wantErr: true,
+		},
+		{
+			name: "default value longer than max length",
+			build: func(b pdf.CellBuilder) pdf.CellBuilder {
+				return b.WithTextField("code", "ÄBC").WithMaxLength(3).WithDefaultValue("ABCD")
+			},
+			want:    pdf.FormField{Type: pdf.FormFieldText, Name: "code", Value: "ÄBC", MaxLength: 3, DefaultValue: "ABCD"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.build(pdf.NewCellBuilder().WithProps(props))
+			if err := b.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, want error %v", err, tt.wantErr)
+			}
+			cell := b.Build()
+			if cell.FormField == nil {
+				t.Fatal("cell has no form field")
+			}
+			if got := *cell.FormField; fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("field = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("attributes without a field", func(t *testing.T) {
+		if err := pdf.NewCellBuilder().WithProps(props).WithRequired().Err(); err == nil {
+			t.Error("Err() = nil for attributes on a cell without a field")
+		}
+	})
+}
+
+func TestDocumentBuilderTabIndexes(t *testing.T) {
+	props := "font1:10:000:left:1:1:1:1"
+	field := func(name string, tabIndex int) pdf.Table {
+		cell := pdf.NewCellBuilder().WithProps(props).WithTextField(name, "").WithTabIndex(tabIndex).Build()
+		return pdf.NewTableBuilder().WithColumns(1, []float64{1}).AddRow(cell).Build()
+	}
+
+	tests := []struct {
+		name    string
+		build   func(pdf.DocumentBuilder) pdf.DocumentBuilder
+		wantErr bool
+	}{
+		{"distinct", func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+			return b.AddTable(field("a", 1)).AddTable(field("b", 2)).AddTable(field("c", 0)).AddTable(field("d", 0))
+		}, false},
+		{"duplicate", func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+			return b.AddTable(field("a", 1)).AddTable(field("b", 1))
+		}, true},
+		{"duplicate in a group", func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+			return b.AddTable(field("a", 2)).AddGroup(field("b", 2))
+		}, true},
+		{"duplicate of the title table", func(b pdf.DocumentBuilder) pdf.DocumentBuilder {
+			return b.WithTitleTable(field("a", 5)).AddTable(field("b", 5))
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.build(pdf.NewDocumentBuilder())
+			if err := b.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Err() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
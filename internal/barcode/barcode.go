@@ -0,0 +1,226 @@
+// Package barcode encodes Code 128, EAN-13 and QR Code symbols and renders
+// them to PNG. Only the standard library is used.
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Symbology is a barcode format.
+type Symbology string
+
+// Supported symbologies.
+const (
+	Code128 Symbology = "code128"
+	EAN13   Symbology = "ean13"
+	QR      Symbology = "qr"
+)
+
+// Level is the error correction level of a QR code: the share of the symbol
+// that can be damaged while it still scans.
+type Level int
+
+// QR code error correction levels.
+const (
+	LevelL Level = iota // about 7% of the codewords
+	LevelM              // about 15%; the default
+	LevelQ              // about 25%
+	LevelH              // about 30%
+)
+
+// Default sizes, in modules.
+const (
+	defaultBarHeight = 50
+	quietZoneCode128 = 10
+	quietZoneEAN13   = 11
+	quietZoneQR      = 4
+)
+
+// Errors returned by Encode.
+var (
+	ErrUnknownSymbology = errors.New("barcode: unknown symbology")
+	ErrInvalidData      = errors.New("barcode: data cannot be encoded")
+)
+
+// Option configures the encoding of a symbol.
+type Option func(*options)
+
+type options struct {
+	level     Level
+	quietZone int
+	barHeight int
+}
+
+// WithLevel sets the error correction level of QR codes. It is ignored by
+// linear symbologies.
+func WithLevel(level Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithQuietZone sets the blank margin around the symbol in modules. It
+// defaults to 10 for Code 128, 11 for EAN-13 and 4 for QR codes, the
+// minimums of their specifications.
+func WithQuietZone(modules int) Option {
+	return func(o *options) {
+		o.quietZone = modules
+	}
+}
+
+// WithBarHeight sets the height of the bars of linear symbols in modules.
+// It defaults to 50 and is ignored by QR codes.
+func WithBarHeight(modules int) Option {
+	return func(o *options) {
+		o.barHeight = modules
+	}
+}
+
+// Barcode is an encoded symbol: a grid of dark and light modules surrounded
+// by a quiet zone. Linear symbols have one row of bars repeated over the
+// bar height.
+type Barcode struct {
+	symbology Symbology
+	cols      int
+	rows      int
+	quietZone int
+	modules   []bool // cols by rows, without the quiet zone
+	linear    bool
+}
+
+// Encode encodes data in the given symbology.
+func Encode(symbology Symbology, data string, opts ...Option) (*Barcode, error) {
+	o := options{level: LevelM, quietZone: -1, barHeight: defaultBarHeight}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if data == "" {
+		return nil, fmt.Errorf("%w: data is empty", ErrInvalidData)
+	}
+	if o.barHeight < 1 {
+		o.barHeight = 1
+	}
+
+	var bars []bool
+	var err error
+	quietZone := 0
+	switch symbology {
+	case Code128:
+		bars, err = encodeCode128(data)
+		quietZone = quietZoneCode128
+	case EAN13:
+		bars, err = encodeEAN13(data)
+		quietZone = quietZoneEAN13
+	case QR:
+		if o.level < LevelL || o.level > LevelH {
+			return nil, fmt.Errorf("%w: unknown error correction level %d", ErrInvalidData, o.level)
+		}
+		q, err := encodeQR([]byte(data), o.level)
+		if err != nil {
+			return nil, err
+		}
+		if o.quietZone >= 0 {
+			q.quietZone = o.quietZone
+		}
+		return q, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSymbology, symbology)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.quietZone >= 0 {
+		quietZone = o.quietZone
+	}
+	return &Barcode{
+		symbology: symbology,
+		cols:      len(bars),
+		rows:      o.barHeight,
+		quietZone: quietZone,
+		modules:   bars,
+		linear:    true,
+	}, nil
+}
+
+// Symbology returns the format of the symbol.
+func (b *Barcode) Symbology() Symbology {
+	return b.symbology
+}
+
+// Linear reports whether the symbol is made of bars rather than a grid.
+func (b *Barcode) Linear() bool {
+	return b.linear
+}
+
+// QuietZone returns the width of the margin around the symbol in modules.
+// Linear symbols only have it on their left and right.
+func (b *Barcode) QuietZone() int {
+	return b.quietZone
+}
+
+// Size returns the width and height of the symbol in modules, including
+// its quiet zone.
+func (b *Barcode) Size() (width, height int) {
+	if b.linear {
+		return b.cols + 2*b.quietZone, b.rows
+	}
+	return b.cols + 2*b.quietZone, b.rows + 2*b.quietZone
+}
+
+// Dark reports whether the module at column x and row y, counted from the
+// top-left corner of the quiet zone, is dark.
+func (b *Barcode) Dark(x, y int) bool {
+	x -= b.quietZone
+	if !b.linear {
+		y -= b.quietZone
+	}
+	if x < 0 || x >= b.cols || y < 0 || y >= b.rows {
+		return false
+	}
+	if b.linear {
+		return b.modules[x]
+	}
+	return b.modules[y*b.cols+x]
+}
+
+// PNG renders the symbol as a black and white PNG with scale pixels per
+// module.
+func (b *Barcode) PNG(scale int) ([]byte, error) {
+	scale = max(scale, 1)
+	w, h := b.Size()
+	img := image.NewPaletted(image.Rect(0, 0, w*scale, h*scale), color.Palette{color.White, color.Black})
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !b.Dark(x, y) {
+				continue
+			}
+			for py := y * scale; py < (y+1)*scale; py++ {
+				row := img.Pix[py*img.Stride:]
+				for px := x * scale; px < (x+1)*scale; px++ {
+					row[px] = 1
+				}
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// widthsToBars expands alternating bar and space widths, starting with a
+// bar, into modules.
+func widthsToBars(bars []bool, widths string) []bool {
+	for i, c := range widths {
+		for n := 0; n < int(c-'0'); n++ {
+			bars = append(bars, i%2 == 0)
+		}
+	}
+	return bars
+}
@@ -0,0 +1,346 @@
+package barcode
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// bars returns the modules of a linear symbol without its quiet zone as a
+// string of '1' for bars and '0' for spaces.
+func bars(b *Barcode) string {
+	var sb strings.Builder
+	for x := b.QuietZone(); x < b.QuietZone()+b.cols; x++ {
+		if b.Dark(x, 0) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+func TestEANCheckDigit(t *testing.T) {
+	tests := []struct {
+		data  string
+		check int
+	}{
+		{"400638133393", 1},
+		{"590123412345", 7},
+		{"978020137962", 4},
+		{"003600029145", 2},
+		{"123456789012", 8},
+		{"000000000000", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			digits := make([]int, len(tt.data))
+			for i, c := range tt.data {
+				digits[i] = int(c - '0')
+			}
+			if got := eanCheckDigit(digits); got != tt.check {
+				t.Errorf("eanCheckDigit(%s) = %d, want %d", tt.data, got, tt.check)
+			}
+
+			full := tt.data + string(rune('0'+tt.check))
+			short, err := Encode(EAN13, tt.data)
+			if err != nil {
+				t.Fatalf("Encode(%s): %v", tt.data, err)
+			}
+			long, err := Encode(EAN13, full)
+			if err != nil {
+				t.Fatalf("Encode(%s): %v", full, err)
+			}
+			if bars(short) != bars(long) {
+				t.Error("12 digits and 13 digits with the check digit encode differently")
+			}
+
+			wrong := tt.data + string(rune('0'+(tt.check+1)%10))
+			if _, err := Encode(EAN13, wrong); !errors.Is(err, ErrInvalidData) {
+				t.Errorf("Encode(%s) error = %v, want %v", wrong, err, ErrInvalidData)
+			}
+		})
+	}
+}
+
+func TestEAN13(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string // modules without the quiet zone
+		wantErr bool
+	}{
+		{name: "reference", data: "5901234123457",
+			want: "10100010110100111011001100100110111101001110101010110011011011001000010101110010011101000100101"},
+		{name: "too short", data: "59012341234", wantErr: true},
+		{name: "too long", data: "59012341234570", wantErr: true},
+		{name: "not digits", data: "59012341234A", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Encode(EAN13, tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidData) {
+					t.Fatalf("Encode error = %v, want %v", err, ErrInvalidData)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if got := bars(b); got != tt.want {
+				t.Errorf("modules\n got %s\nwant %s", got, tt.want)
+			}
+			if w, _ := b.Size(); w != 95+2*quietZoneEAN13 {
+				t.Errorf("width = %d, want %d", w, 95+2*quietZoneEAN13)
+			}
+		})
+	}
+}
+
+// code128Values decodes the symbol values of a Code 128 symbol, start and
+// check symbols included, and checks its check symbol and stop pattern.
+func code128Values(t *testing.T, modules string) []int {
+	t.Helper()
+	var widths []byte
+	for i := 0; i < len(modules); {
+		n := 1
+		for i+n < len(modules) && modules[i+n] == modules[i] {
+			n++
+		}
+		widths = append(widths, byte('0'+n))
+		i += n
+	}
+	stop := code128Patterns[code128Stop]
+	if !strings.HasSuffix(string(widths), stop) || (len(widths)-len(stop))%6 != 0 {
+		t.Fatalf("symbol %s does not end with the stop pattern after whole symbols", widths)
+	}
+
+	var values []int
+	for i := 0; i < len(widths)-len(stop); i += 6 {
+		v := slices.Index(code128Patterns[:code128Stop], string(widths[i:i+6]))
+		if v < 0 {
+			t.Fatalf("unknown pattern %s", widths[i:i+6])
+		}
+		values = append(values, v)
+	}
+	sum := values[0]
+	for i, v := range values[1 : len(values)-1] {
+		sum += (i + 1) * v
+	}
+	if check := values[len(values)-1]; check != sum%103 {
+		t.Errorf("check symbol = %d, want %d", check, sum%103)
+	}
+	return values[:len(values)-1]
+}
+
+func TestCode128SetSwitching(t *testing.T) {
+	const (
+		startA = 103
+		startB = 104
+		startC = 105
+		codeA  = 101
+		codeB  = 100
+		codeC  = 99
+	)
+	tests := []struct {
+		name string
+		data string
+		want []int
+	}{
+		{"text uses set B", "HELLO", []int{startB, 40, 37, 44, 44, 47}},
+		{"digits use set C", "123456", []int{startC, 12, 34, 56}},
+		{"two digits use set C", "12", []int{startC, 12}},
+		{"one digit stays in set B", "1", []int{startB, 17}},
+		{"digit run inside text", "abc123456def", []int{startB, 65, 66, 67, codeC, 12, 34, 56, codeB, 68, 69, 70}},
+		{"short digit run stays in set B", "ab1234cd", []int{startB, 65, 66, 17, 18, 19, 20, 67, 68}},
+		{"odd trailing digits", "X12345", []int{startB, 56, 17, codeC, 23, 45}},
+		{"leading digits then text", "1234ab", []int{startC, 12, 34, codeB, 65, 66}},
+		{"control character uses set A", "\tA", []int{startA, 73, 33}},
+		{"control character after lowercase", "a\n", []int{startB, 65, codeA, 74}},
+		{"lowercase after control character", "AB\x01cd", []int{startA, 33, 34, 65, codeB, 67, 68}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Encode(Code128, tt.data)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if got := code128Values(t, bars(b)); !slices.Equal(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("reference", func(t *testing.T) {
+		b, err := Encode(Code128, "abc123456def")
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		const want = "110100100001001011000010010000110100001011001011101111010110011100100010110001110001011010111101110100001001101011001000010110000100101111011101100011101011"
+		if got := bars(b); got != want {
+			t.Errorf("modules\n got %s\nwant %s", got, want)
+		}
+	})
+
+	t.Run("non-ASCII", func(t *testing.T) {
+		if _, err := Encode(Code128, "café"); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("Encode error = %v, want %v", err, ErrInvalidData)
+		}
+	})
+}
+
+// readMatrix reads a reference QR matrix from testdata: one row per line,
+// '#' for dark modules, without the quiet zone.
+func readMatrix(t *testing.T, name string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+// matrixRows renders a QR matrix as rows of '#' and '.'.
+func matrixRows(q *qrMatrix) []string {
+	rows := make([]string, q.size)
+	for y := range rows {
+		var sb strings.Builder
+		for x := 0; x < q.size; x++ {
+			if q.dark(x, y) {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		rows[y] = sb.String()
+	}
+	return rows
+}
+
+func TestQRReferenceMatrices(t *testing.T) {
+	// The reference symbols come from another encoder. Implementations
+	// weigh the mask penalties differently, so each symbol is compared
+	// with the mask the reference picked.
+	tests := []struct {
+		file  string
+		data  string
+		level Level
+		mask  int
+	}{
+		{"numeric-v1-M.txt", "01234567", LevelM, 0},
+		{"alphanumeric-v1-Q.txt", "HELLO WORLD", LevelQ, 6},
+		{"byte-v3-M.txt", "https://example.com/invoice?id=42", LevelM, 2},
+		{"alphanumeric-v5-Q.txt", "GOPDFSUIT CLIENT 0123456789 $%*+-./: ABCDEFGHIJKLMNOPQRSTUVWXYZ GOPDFSUIT CLIENT 01234", LevelQ, 4},
+		{"byte-v7-L.txt", strings.Repeat("The quick brown fox jumps over the lazy dog. ", 4)[:140], LevelL, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			want := readMatrix(t, tt.file)
+			q, err := qrSymbol([]byte(tt.data), tt.level)
+			if err != nil {
+				t.Fatalf("qrSymbol: %v", err)
+			}
+			q.applyMask(tt.mask)
+			q.drawFormat(tt.level, tt.mask)
+			got := matrixRows(q)
+			if len(got) != len(want) {
+				t.Fatalf("size = %d, want %d", len(got), len(want))
+			}
+			for y := range want {
+				if got[y] != want[y] {
+					t.Errorf("row %d\n got %s\nwant %s", y, got[y], want[y])
+				}
+			}
+		})
+	}
+}
+
+func TestQRLowestPenaltyMask(t *testing.T) {
+	tests := []struct {
+		data  string
+		level Level
+	}{
+		{"01234567", LevelM},
+		{"HELLO WORLD", LevelQ},
+		{"https://example.com/invoice?id=42", LevelH},
+		{strings.Repeat("gopdfsuit ", 20), LevelL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			best, bestPenalty := -1, 0
+			var want []bool
+			for mask := 0; mask < 8; mask++ {
+				q, err := qrSymbol([]byte(tt.data), tt.level)
+				if err != nil {
+					t.Fatalf("qrSymbol: %v", err)
+				}
+				q.applyMask(mask)
+				q.drawFormat(tt.level, mask)
+				if p := q.penalty(); best < 0 || p < bestPenalty {
+					best, bestPenalty, want = mask, p, q.modules
+				}
+			}
+
+			b, err := Encode(QR, tt.data, WithLevel(tt.level))
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if !slices.Equal(b.modules, want) {
+				t.Errorf("symbol does not use mask %d, the one with the lowest penalty", best)
+			}
+			if w, h := b.Size(); w != h || w != b.cols+2*quietZoneQR {
+				t.Errorf("size = %dx%d, want a square with a %d module quiet zone", w, h, quietZoneQR)
+			}
+		})
+	}
+}
+
+func TestQRLinePenalty(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want int
+	}{
+		{"alternating", "#.#.#.#.#.#.#.#.#.#.#", 0},
+		{"run of five", "#####.#.#.#.#.#.#.#.#", 3},
+		{"run of seven", ".......#.#.#.#.#.#.#.", 5},
+		{"finder with light before", "....#.###.#.#.#.#.#.#", 40},
+		{"finder at the edge", "#.###.##.#.#.#.#.#.#.", 40},
+		{"finder without light around", "#.#.###.#.#.#.#.#.#.#", 0},
+		{"finder and runs", "#.###.#.........#####", 40 + 3 + 9 - 5 + 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := make([]bool, len(tt.line))
+			for i, c := range tt.line {
+				line[i] = c == '#'
+			}
+			if got := linePenalty(line); got != tt.want {
+				t.Errorf("linePenalty(%s) = %d, want %d", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQRErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts []Option
+	}{
+		{"empty", "", nil},
+		{"too long", strings.Repeat("x", 1300), []Option{WithLevel(LevelH)}},
+		{"unknown level", "data", []Option{WithLevel(Level(7))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Encode(QR, tt.data, tt.opts...); !errors.Is(err, ErrInvalidData) {
+				t.Fatalf("Encode error = %v, want %v", err, ErrInvalidData)
+			}
+		})
+	}
+}
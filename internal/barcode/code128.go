@@ -0,0 +1,114 @@
+package barcode
+
+import "fmt"
+
+// code128Patterns are the bar and space widths of the Code 128 symbol
+// values 0 to 105 and of the stop pattern.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// Code 128 code sets and their special symbol values.
+const (
+	code128A = iota
+	code128B
+	code128C
+
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128CodeA  = 101
+	code128StartA = 103
+	code128Stop   = 106
+)
+
+// encodeCode128 encodes ASCII text, switching between code sets A, B and C
+// so that runs of digits take half the width.
+func encodeCode128(data string) ([]bool, error) {
+	for _, r := range data {
+		if r > 127 {
+			return nil, fmt.Errorf("%w: Code 128 only encodes ASCII, got %q", ErrInvalidData, r)
+		}
+	}
+
+	digits := func(i int) int {
+		n := 0
+		for i+n < len(data) && '0' <= data[i+n] && data[i+n] <= '9' {
+			n++
+		}
+		return n
+	}
+	// textSet returns code set A when a control character comes before
+	// any lowercase letter from i on, and code set B otherwise.
+	textSet := func(i int) int {
+		for ; i < len(data); i++ {
+			switch c := data[i]; {
+			case c < 32:
+				return code128A
+			case c >= 96:
+				return code128B
+			}
+		}
+		return code128B
+	}
+	char := func(set int, c byte) int {
+		if set == code128A && c < 32 {
+			return int(c) + 64
+		}
+		return int(c) - 32
+	}
+
+	set := textSet(0)
+	if n := digits(0); n >= 4 || (n == len(data) && n >= 2) {
+		set = code128C
+	}
+	values := []int{code128StartA + set}
+	for i := 0; i < len(data); {
+		if set == code128C {
+			if digits(i) >= 2 {
+				values = append(values, int(data[i]-'0')*10+int(data[i+1]-'0'))
+				i += 2
+				continue
+			}
+			set = textSet(i)
+			values = append(values, code128CodeA-set)
+			continue
+		}
+		if n := digits(i); n >= 6 || (n >= 4 && i+n == len(data)) {
+			if n%2 == 1 {
+				values = append(values, char(set, data[i]))
+				i++
+			}
+			values = append(values, code128CodeC)
+			set = code128C
+			continue
+		}
+		if c := data[i]; (set == code128A && c >= 96) || (set == code128B && c < 32) {
+			set = textSet(i)
+			values = append(values, code128CodeA-set)
+		}
+		values = append(values, char(set, data[i]))
+		i++
+	}
+
+	checksum := values[0]
+	for i, v := range values[1:] {
+		checksum += (i + 1) * v
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var bars []bool
+	for _, v := range values {
+		bars = widthsToBars(bars, code128Patterns[v])
+	}
+	return bars, nil
+}
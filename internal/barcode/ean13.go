@@ -0,0 +1,72 @@
+package barcode
+
+import "fmt"
+
+// eanDigits are the L-code patterns of the digits 0 to 9. G-codes are the
+// reversed R-codes, which are the L-codes with bars and spaces swapped.
+var eanDigits = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// eanParity gives, by the first digit, whether each digit of the left half
+// is L-coded or G-coded.
+var eanParity = [10]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+// encodeEAN13 encodes 12 digits, adding the check digit, or 13 digits whose
+// check digit is verified.
+func encodeEAN13(data string) ([]bool, error) {
+	if len(data) != 12 && len(data) != 13 {
+		return nil, fmt.Errorf("%w: EAN-13 takes 12 or 13 digits, got %d characters", ErrInvalidData, len(data))
+	}
+	digits := make([]int, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] < '0' || data[i] > '9' {
+			return nil, fmt.Errorf("%w: EAN-13 only encodes digits, got %q", ErrInvalidData, data[i])
+		}
+		digits[i] = int(data[i] - '0')
+	}
+	check := eanCheckDigit(digits[:12])
+	if len(digits) == 13 && digits[12] != check {
+		return nil, fmt.Errorf("%w: EAN-13 check digit is %d, expected %d", ErrInvalidData, digits[12], check)
+	}
+	digits = append(digits[:12], check)
+
+	bars := make([]bool, 0, 95)
+	module := func(pattern string, invert, reverse bool) {
+		for i := range pattern {
+			c := pattern[i]
+			if reverse {
+				c = pattern[len(pattern)-1-i]
+			}
+			bars = append(bars, (c == '1') != invert)
+		}
+	}
+	module("101", false, false)
+	for i, d := range digits[1:7] {
+		g := eanParity[digits[0]][i] == 'G'
+		module(eanDigits[d], g, g)
+	}
+	module("01010", false, false)
+	for _, d := range digits[7:] {
+		module(eanDigits[d], true, false)
+	}
+	module("101", false, false)
+	return bars, nil
+}
+
+// eanCheckDigit computes the check digit of the first 12 digits: their sum,
+// weighting every second digit by 3, rounded up to a multiple of ten.
+func eanCheckDigit(digits []int) int {
+	sum := 0
+	for i, d := range digits {
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
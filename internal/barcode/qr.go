@@ -0,0 +1,557 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error correction codewords per block and number of blocks, by level and
+// version. Index 0 is unused.
+var (
+	qrECCPerBlock = [4][41]int{
+		{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+		{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	}
+	qrBlocks = [4][41]int{
+		{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+		{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+		{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+		{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+	}
+)
+
+// qrFormatLevel is the level indicator of the format information.
+var qrFormatLevel = [4]int{LevelL: 1, LevelM: 0, LevelQ: 3, LevelH: 2}
+
+const (
+	qrMinVersion   = 1
+	qrMaxVersion   = 40
+	qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+)
+
+// qrMode is a data encoding mode with its indicator and the width of its
+// character count for versions 1-9, 10-26 and 27-40.
+type qrMode struct {
+	indicator  int
+	countWidth [3]int
+}
+
+var (
+	qrNumeric  = qrMode{0x1, [3]int{10, 12, 14}}
+	qrAlphanum = qrMode{0x2, [3]int{9, 11, 13}}
+	qrByte     = qrMode{0x4, [3]int{8, 16, 16}}
+)
+
+func (m qrMode) countBits(version int) int {
+	switch {
+	case version <= 9:
+		return m.countWidth[0]
+	case version <= 26:
+		return m.countWidth[1]
+	default:
+		return m.countWidth[2]
+	}
+}
+
+// bitBuffer accumulates bits most significant first.
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 == 1)
+	}
+}
+
+// encodeQR encodes data in a single segment using the most compact mode it
+// allows, in the smallest version that holds it at the given level.
+func encodeQR(data []byte, level Level) (*Barcode, error) {
+	q, err := qrSymbol(data, level)
+	if err != nil {
+		return nil, err
+	}
+	q.applyBestMask(level)
+	return &Barcode{
+		symbology: QR,
+		cols:      q.size,
+		rows:      q.size,
+		quietZone: quietZoneQR,
+		modules:   q.modules,
+	}, nil
+}
+
+// qrSymbol lays out the function patterns and the codewords of data before
+// a mask is applied.
+func qrSymbol(data []byte, level Level) (*qrMatrix, error) {
+	mode, payload := qrSegment(data)
+	version := 0
+	for v := qrMinVersion; v <= qrMaxVersion; v++ {
+		if len(data) < 1<<mode.countBits(v) && 4+mode.countBits(v)+len(payload) <= qrDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes do not fit in a QR code at this error correction level", ErrInvalidData, len(data))
+	}
+
+	var bits bitBuffer
+	bits.append(mode.indicator, 4)
+	bits.append(len(data), mode.countBits(version))
+	bits = append(bits, payload...)
+	capacity := qrDataCodewords(version, level) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	q := newQRMatrix(version)
+	q.drawFunctionPatterns(level)
+	q.drawCodewords(qrInterleave(codewords, version, level))
+	return q, nil
+}
+
+// qrSegment picks the numeric, alphanumeric or byte mode for data and
+// returns its encoded bits.
+func qrSegment(data []byte) (qrMode, bitBuffer) {
+	numeric, alphanumeric := true, true
+	for _, c := range data {
+		numeric = numeric && '0' <= c && c <= '9'
+		alphanumeric = alphanumeric && strings.IndexByte(qrAlphanumeric, c) >= 0
+	}
+	var bits bitBuffer
+	switch {
+	case numeric:
+		for i := 0; i < len(data); i += 3 {
+			n := min(3, len(data)-i)
+			value := 0
+			for _, c := range data[i : i+n] {
+				value = value*10 + int(c-'0')
+			}
+			bits.append(value, n*3+1)
+		}
+		return qrNumeric, bits
+	case alphanumeric:
+		for i := 0; i+1 < len(data); i += 2 {
+			bits.append(strings.IndexByte(qrAlphanumeric, data[i])*45+strings.IndexByte(qrAlphanumeric, data[i+1]), 11)
+		}
+		if len(data)%2 == 1 {
+			bits.append(strings.IndexByte(qrAlphanumeric, data[len(data)-1]), 6)
+		}
+		return qrAlphanum, bits
+	default:
+		for _, c := range data {
+			bits.append(int(c), 8)
+		}
+		return qrByte, bits
+	}
+}
+
+// qrRawModules returns the number of modules of a version that hold
+// codewords, including the remainder bits.
+func qrRawModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+// qrDataCodewords returns the number of data codewords of a version at the
+// given level.
+func qrDataCodewords(version int, level Level) int {
+	return qrRawModules(version)/8 - qrECCPerBlock[level][version]*qrBlocks[level][version]
+}
+
+// qrInterleave splits the data codewords into blocks, appends the error
+// correction codewords of each and interleaves the blocks.
+func qrInterleave(data []byte, version int, level Level) []byte {
+	numBlocks := qrBlocks[level][version]
+	eccLen := qrECCPerBlock[level][version]
+	raw := qrRawModules(version) / 8
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw / numBlocks
+
+	divisor := rsDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortLen - eccLen
+		if i >= numShort {
+			n++
+		}
+		block := append([]byte(nil), data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(block, divisor)
+		if i < numShort {
+			block = append(block, 0) // aligns the ECC with that of long blocks
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	out := make([]byte, 0, raw)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortLen-eccLen || j >= numShort {
+				out = append(out, block[i])
+			}
+		}
+	}
+	return out
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of the given
+// degree, without its leading coefficient.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords of data.
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrMatrix is a QR code symbol being drawn.
+type qrMatrix struct {
+	version  int
+	size     int
+	modules  []bool
+	function []bool // modules of finder, timing, alignment and format patterns
+}
+
+func newQRMatrix(version int) *qrMatrix {
+	size := version*4 + 17
+	return &qrMatrix{
+		version:  version,
+		size:     size,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+}
+
+func (q *qrMatrix) dark(x, y int) bool {
+	return q.modules[y*q.size+x]
+}
+
+func (q *qrMatrix) setFunction(x, y int, dark bool) {
+	q.modules[y*q.size+x] = dark
+	q.function[y*q.size+x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns and
+// reserves the format and version information.
+func (q *qrMatrix) drawFunctionPatterns(level Level) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	align := qrAlignmentPositions(q.version)
+	last := len(align) - 1
+	for i, x := range align {
+		for j, y := range align {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue // overlaps a finder pattern
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	q.drawFormat(level, 0)
+	q.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centered on (cx, cy).
+func (q *qrMatrix) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			q.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// qrAlignmentPositions returns the centers of the alignment patterns along
+// each axis.
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	n := version/7 + 2
+	step := (version*8 + n*3 + 5) / (n*4 - 4) * 2
+	positions := make([]int, n)
+	positions[0] = 6
+	for i, pos := n-1, version*4+10; i > 0; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// drawFormat draws both copies of the format information for the level
+// and mask, BCH-coded and masked.
+func (q *qrMatrix) drawFormat(level Level, mask int) {
+	data := qrFormatLevel[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version information of versions 7
+// and up.
+func (q *qrMatrix) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+	rem := q.version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := q.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the two-module wide columns that
+// zigzag up and down from the bottom-right corner, skipping the function
+// patterns.
+func (q *qrMatrix) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skips the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < q.size; vert++ {
+			y := vert
+			if upward {
+				y = q.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.function[y*q.size+x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y*q.size+x] = data[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by one of the eight mask
+// patterns. Applying it twice restores the symbol.
+func (q *qrMatrix) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			default:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y*q.size+x] {
+				q.modules[y*q.size+x] = !q.modules[y*q.size+x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty score and draws
+// its format information.
+func (q *qrMatrix) applyBestMask(level Level) {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(level, mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(level, best)
+}
+
+// Penalty weights of the mask evaluation rules.
+const (
+	penaltyRun     = 3
+	penaltyBlock   = 3
+	penaltyFinder  = 40
+	penaltyBalance = 10
+)
+
+// penalty scores the symbol by the four rules of the specification: long
+// runs of one color, 2x2 blocks, finder-like patterns and an unbalanced
+// share of dark modules.
+func (q *qrMatrix) penalty() int {
+	total := 0
+	line := make([]bool, q.size)
+	for _, vertical := range []bool{false, true} {
+		for i := 0; i < q.size; i++ {
+			for j := range line {
+				if vertical {
+					line[j] = q.dark(i, j)
+				} else {
+					line[j] = q.dark(j, i)
+				}
+			}
+			total += linePenalty(line)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			c := q.dark(x, y)
+			if c {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size && c == q.dark(x+1, y) && c == q.dark(x, y+1) && c == q.dark(x+1, y+1) {
+				total += penaltyBlock
+			}
+		}
+	}
+	cells := q.size * q.size
+	k := (abs(dark*20-cells*10)+cells-1)/cells - 1
+	return total + k*penaltyBalance
+}
+
+// qrFinderLike is the 1:1:3:1:1 pattern of a finder, dark first.
+var qrFinderLike = []bool{true, false, true, true, true, false, true}
+
+// linePenalty scores the runs and finder-like patterns of a row or column.
+// Modules outside the symbol count as light.
+func linePenalty(line []bool) int {
+	total := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			total += penaltyRun + run - 5
+		}
+		run = 1
+	}
+
+	light := func(from, to int) bool {
+		for i := from; i < to; i++ {
+			if i >= 0 && i < len(line) && line[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for i := 0; i+len(qrFinderLike) <= len(line); i++ {
+		match := true
+		for j, c := range qrFinderLike {
+			if line[i+j] != c {
+				match = false
+				break
+			}
+		}
+		if match && (light(i-4, i) || light(i+7, i+11)) {
+			total += penaltyFinder
+		}
+	}
+	return total
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -2,12 +2,17 @@
 package builder
 
 import (
+	"fmt"
+	"unicode/utf8"
+
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 )
 
 // cellBuilder implements the CellBuilder interface.
 type cellBuilder struct {
-	cell domain.Cell
+	cell  domain.Cell
+	err   error                     // first color that could not be parsed
+	attrs []func(*domain.FormField) // field attributes, applied to every field set
 }
 
 // NewCellBuilder creates a new CellBuilder instance.
@@ -23,6 +28,12 @@ func (b *cellBuilder) WithProps(props string) domain.CellBuilder {
 	return b
 }
 
+// WithStyle sets the cell properties from a typed Style.
+func (b *cellBuilder) WithStyle(style domain.Style) domain.CellBuilder {
+	b.cell.SetStyle(style)
+	return b
+}
+
 // WithText sets the cell text.
 func (b *cellBuilder) WithText(text string) domain.CellBuilder {
 	b.cell.Text = text
@@ -31,38 +42,224 @@ func (b *cellBuilder) WithText(text string) domain.CellBuilder {
 
 // WithTextField adds a text form field.
 func (b *cellBuilder) WithTextField(name, value string) domain.CellBuilder {
-	b.cell.FormField = &domain.FormField{
+	return b.setField(&domain.FormField{
 		Type:  domain.FormFieldText,
 		Name:  name,
 		Value: value,
-	}
-	return b
+	})
 }
 
 // WithCheckbox adds a checkbox form field.
 func (b *cellBuilder) WithCheckbox(name, value string, checked bool) domain.CellBuilder {
-	b.cell.FormField = &domain.FormField{
+	return b.setField(&domain.FormField{
 		Type:    domain.FormFieldCheckbox,
 		Name:    name,
 		Value:   value,
 		Checked: checked,
-	}
-	return b
+	})
 }
 
 // WithRadio adds a radio button form field.
 func (b *cellBuilder) WithRadio(name, value, groupName string, checked bool) domain.CellBuilder {
-	b.cell.FormField = &domain.FormField{
+	return b.setField(&domain.FormField{
 		Type:      domain.FormFieldRadio,
 		Name:      name,
 		Value:     value,
 		GroupName: groupName,
 		Checked:   checked,
 		Shape:     "round",
+	})
+}
+
+// WithDropdown adds a combo box with the given options and selected value.
+func (b *cellBuilder) WithDropdown(name string, options []string, value string) domain.CellBuilder {
+	return b.setField(&domain.FormField{
+		Type:    domain.FormFieldDropdown,
+		Name:    name,
+		Value:   value,
+		Options: options,
+	})
+}
+
+// WithListbox adds a list box. More than one selection makes it multi-select.
+func (b *cellBuilder) WithListbox(name string, options []string, selected ...string) domain.CellBuilder {
+	return b.setField(listboxField(name, options, selected))
+}
+
+// WithMultiline adds a multiline text area.
+func (b *cellBuilder) WithMultiline(name, value string) domain.CellBuilder {
+	return b.setField(&domain.FormField{
+		Type:  domain.FormFieldMultiline,
+		Name:  name,
+		Value: value,
+	})
+}
+
+// WithDate adds a date field. An empty format uses domain.DefaultDateFormat.
+func (b *cellBuilder) WithDate(name, value, format string) domain.CellBuilder {
+	return b.setField(&domain.FormField{
+		Type:       domain.FormFieldDate,
+		Name:       name,
+		Value:      value,
+		DateFormat: format,
+	})
+}
+
+// WithSignature adds an empty signature field.
+func (b *cellBuilder) WithSignature(name string) domain.CellBuilder {
+	return b.setField(&domain.FormField{
+		Type: domain.FormFieldSignature,
+		Name: name,
+	})
+}
+
+// WithRequired marks the form field as required. Like the other field
+// attributes it may be set before or after the field.
+func (b *cellBuilder) WithRequired() domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.Required = true })
+}
+
+// WithReadOnly makes the form field read-only.
+func (b *cellBuilder) WithReadOnly() domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.ReadOnly = true })
+}
+
+// WithMaxLength limits the characters of a text, multiline or date field.
+func (b *cellBuilder) WithMaxLength(n int) domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.MaxLength = n })
+}
+
+// WithTooltip sets the form field tooltip.
+func (b *cellBuilder) WithTooltip(text string) domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.Tooltip = text })
+}
+
+// WithTabIndex sets the tab order position of the form field.
+func (b *cellBuilder) WithTabIndex(index int) domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.TabIndex = index })
+}
+
+// WithDefaultValue sets the value the form field resets to.
+func (b *cellBuilder) WithDefaultValue(value string) domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.DefaultValue = value })
+}
+
+// WithPattern sets a regular expression the whole field value must match.
+func (b *cellBuilder) WithPattern(pattern string) domain.CellBuilder {
+	return b.withField(func(f *domain.FormField) { f.Pattern = pattern })
+}
+
+// withField applies set to the cell's form field and keeps it for a field
+// set later.
+func (b *cellBuilder) withField(set func(*domain.FormField)) domain.CellBuilder {
+	b.attrs = append(b.attrs, set)
+	if b.cell.FormField != nil {
+		set(b.cell.FormField)
+	}
+	return b
+}
+
+// setField sets the cell's form field with the attributes set so far.
+func (b *cellBuilder) setField(f *domain.FormField) domain.CellBuilder {
+	for _, set := range b.attrs {
+		set(f)
 	}
+	b.cell.FormField = f
+	return b
+}
+
+// WithRuns sets styled text runs, keeping their plain text as the cell text.
+func (b *cellBuilder) WithRuns(runs ...domain.TextRun) domain.CellBuilder {
+	b.cell.Runs = runs
+	b.cell.Text = domain.PlainText(runs)
+	return b
+}
+
+// WithMarkup sets text runs parsed from inline markup, styled from the cell
+// props set so far.
+func (b *cellBuilder) WithMarkup(markup string) domain.CellBuilder {
+	return b.WithRuns(Markup(baseStyle(b.cell), markup)...)
+}
+
+// WithLink makes the cell a link to an absolute URL or, with a target from
+// domain.AnchorLink, to a named anchor.
+func (b *cellBuilder) WithLink(target string) domain.CellBuilder {
+	b.cell.Link = target
 	return b
 }
 
+// WithImage places an image in the cell.
+func (b *cellBuilder) WithImage(image domain.Image) domain.CellBuilder {
+	b.cell.Image = &image
+	return b
+}
+
+// WithColSpan makes the cell span several columns.
+func (b *cellBuilder) WithColSpan(n int) domain.CellBuilder {
+	b.cell.ColSpan = n
+	return b
+}
+
+// WithRowSpan makes the cell span several rows.
+func (b *cellBuilder) WithRowSpan(n int) domain.CellBuilder {
+	b.cell.RowSpan = n
+	return b
+}
+
+// WithBackground sets the cell background color. Hex ("#EEE", "#F0F0F0"),
+// RGB ("rgb(240, 240, 240)", "240,240,240") and named colors are accepted.
+func (b *cellBuilder) WithBackground(color string) domain.CellBuilder {
+	return b.withColor(&b.cell.BgColor, "background", color)
+}
+
+// WithTextColor sets the text color.
+func (b *cellBuilder) WithTextColor(color string) domain.CellBuilder {
+	return b.withColor(&b.cell.TextColor, "text color", color)
+}
+
+// WithBorderColor sets the border color.
+func (b *cellBuilder) WithBorderColor(color string) domain.CellBuilder {
+	return b.withColor(&b.cell.BorderColor, "border color", color)
+}
+
+// withColor stores color as "#RRGGBB" in dst, or records the first color
+// that cannot be parsed and leaves dst unchanged.
+func (b *cellBuilder) withColor(dst *domain.Color, what, color string) domain.CellBuilder {
+	c, err := domain.ParseColor(color)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("%s: %w", what, err)
+		}
+		return b
+	}
+	*dst = c
+	return b
+}
+
+// Err returns the first error of a color setter whose color could not be
+// parsed, or else reports field attributes set without a field and a value
+// or default value longer than the maximum length of the field.
+func (b *cellBuilder) Err() error {
+	if b.err != nil {
+		return b.err
+	}
+	f := b.cell.FormField
+	if f == nil {
+		if len(b.attrs) > 0 {
+			return fmt.Errorf("field attributes set on a cell without a form field")
+		}
+		return nil
+	}
+	if f.MaxLength > 0 && f.IsText() {
+		for _, v := range []struct{ what, value string }{{"value", f.Value}, {"default value", f.DefaultValue}} {
+			if n := utf8.RuneCountInString(v.value); n > f.MaxLength {
+				return fmt.Errorf("field %q: %s has %d characters, more than the maximum length %d", f.Name, v.what, n, f.MaxLength)
+			}
+		}
+	}
+	return nil
+}
+
 // Build constructs and returns the final cell.
 func (b *cellBuilder) Build() domain.Cell {
 	return b.cell
@@ -76,6 +273,50 @@ func Cell(props, text string) domain.Cell {
 	}
 }
 
+// StyledCell creates a cell from a typed Style and text.
+func StyledCell(style domain.Style, text string) domain.Cell {
+	cell := domain.Cell{Text: text}
+	cell.SetStyle(style)
+	return cell
+}
+
+// RichCell creates a cell whose text runs are parsed from inline markup.
+func RichCell(props, markup string) domain.Cell {
+	cell := domain.Cell{Props: props}
+	cell.Runs = Markup(baseStyle(cell), markup)
+	cell.Text = domain.PlainText(cell.Runs)
+	return cell
+}
+
+// baseStyle returns the style runs parsed for a cell start from, the
+// default style when the props are not valid.
+func baseStyle(cell domain.Cell) domain.Style {
+	style, err := cell.Style()
+	if err != nil {
+		style = domain.DefaultStyle()
+	}
+	style.Background, style.BorderColor = "", ""
+	return style
+}
+
+// SpanCell creates a cell that spans colspan columns and rowspan rows.
+func SpanCell(props, text string, colspan, rowspan int) domain.Cell {
+	return domain.Cell{
+		Props:   props,
+		Text:    text,
+		ColSpan: colspan,
+		RowSpan: rowspan,
+	}
+}
+
+// ImageCell creates a cell showing an image.
+func ImageCell(props string, image domain.Image) domain.Cell {
+	return domain.Cell{
+		Props: props,
+		Image: &image,
+	}
+}
+
 // TextFieldCell creates a cell with a text form field.
 func TextFieldCell(props, text, name, value string) domain.Cell {
 	return domain.Cell{
@@ -118,3 +359,79 @@ func RadioCell(props, name, value, groupName string, checked bool) domain.Cell {
 		},
 	}
 }
+
+// DropdownCell creates a cell with a combo box.
+func DropdownCell(props, name string, options []string, value string) domain.Cell {
+	return domain.Cell{
+		Props: props,
+		FormField: &domain.FormField{
+			Type:    domain.FormFieldDropdown,
+			Name:    name,
+			Value:   value,
+			Options: options,
+		},
+	}
+}
+
+// ListboxCell creates a cell with a list box. More than one selection makes
+// it multi-select.
+func ListboxCell(props, name string, options []string, selected ...string) domain.Cell {
+	return domain.Cell{
+		Props:     props,
+		FormField: listboxField(name, options, selected),
+	}
+}
+
+// MultilineCell creates a cell with a multiline text area.
+func MultilineCell(props, name, value string) domain.Cell {
+	return domain.Cell{
+		Props: props,
+		FormField: &domain.FormField{
+			Type:  domain.FormFieldMultiline,
+			Name:  name,
+			Value: value,
+		},
+	}
+}
+
+// DateCell creates a cell with a date field. An empty format uses
+// domain.DefaultDateFormat.
+func DateCell(props, name, value, format string) domain.Cell {
+	return domain.Cell{
+		Props: props,
+		FormField: &domain.FormField{
+			Type:       domain.FormFieldDate,
+			Name:       name,
+			Value:      value,
+			DateFormat: format,
+		},
+	}
+}
+
+// SignatureCell creates a cell with an empty signature field.
+func SignatureCell(props, name string) domain.Cell {
+	return domain.Cell{
+		Props: props,
+		FormField: &domain.FormField{
+			Type: domain.FormFieldSignature,
+			Name: name,
+		},
+	}
+}
+
+// listboxField builds a list box, multi-select when more than one option is selected.
+func listboxField(name string, options, selected []string) *domain.FormField {
+	field := &domain.FormField{
+		Type:    domain.FormFieldListbox,
+		Name:    name,
+		Options: options,
+	}
+	switch {
+	case len(selected) > 1:
+		field.MultiSelect = true
+		field.Selected = selected
+	case len(selected) == 1:
+		field.Value = selected[0]
+	}
+	return field
+}
@@ -18,7 +18,7 @@ func NewConfigBuilder() *ConfigBuilder {
 		config: domain.Config{
 			Page:          string(domain.PageSizeA4),
 			PageBorder:    "1:1:1:1",
-			PageAlignment: 1,
+			PageAlignment: domain.PageAlignmentPortrait,
 		},
 	}
 }
@@ -41,6 +41,47 @@ func (b *ConfigBuilder) WithPageAlignment(alignment int) *ConfigBuilder {
 	return b
 }
 
+// WithCustomPage sets a custom page size, for example for labels.
+func (b *ConfigBuilder) WithCustomPage(width, height domain.Length) *ConfigBuilder {
+	b.config.Page = string(domain.PageSizeCustom)
+	b.config.PageWidth = width
+	b.config.PageHeight = height
+	return b
+}
+
+// WithOrientation sets the page orientation. PageAlignment is kept in sync
+// for servers that only understand it.
+func (b *ConfigBuilder) WithOrientation(orientation domain.Orientation) *ConfigBuilder {
+	b.config.Orientation = orientation
+	switch orientation {
+	case domain.OrientationPortrait:
+		b.config.PageAlignment = domain.PageAlignmentPortrait
+	case domain.OrientationLandscape:
+		b.config.PageAlignment = domain.PageAlignmentLandscape
+	}
+	return b
+}
+
+// WithMargins sets the page margins.
+func (b *ConfigBuilder) WithMargins(top, right, bottom, left domain.Length) *ConfigBuilder {
+	b.config.Margins = &domain.Margins{Top: top, Right: right, Bottom: bottom, Left: left}
+	return b
+}
+
+// WithSecurity encrypts the document with the given passwords, permissions
+// and algorithm.
+func (b *ConfigBuilder) WithSecurity(security domain.Security) *ConfigBuilder {
+	b.config.Security = &security
+	return b
+}
+
+// WithSignature requests a digital signature, applied by the client's
+// signer after rendering.
+func (b *ConfigBuilder) WithSignature(sig domain.Signature) *ConfigBuilder {
+	b.config.Signature = &sig
+	return b
+}
+
 // WithWatermark sets the watermark text.
 func (b *ConfigBuilder) WithWatermark(watermark string) *ConfigBuilder {
 	b.config.Watermark = watermark
@@ -54,105 +95,160 @@ func (b *ConfigBuilder) Build() domain.Config {
 
 // PropsBuilder provides a fluent API for building cell property strings.
 type PropsBuilder struct {
-	font      string
-	size      int
-	weight    string
-	alignment string
-	borders   [4]int
+	style domain.Style
+	err   error
 }
 
 // NewPropsBuilder creates a new PropsBuilder with defaults.
 func NewPropsBuilder() *PropsBuilder {
 	return &PropsBuilder{
-		font:      "font1",
-		size:      9,
-		weight:    "000",
-		alignment: "left",
-		borders:   [4]int{1, 1, 1, 1},
+		style: domain.Style{
+			Font:      "font1",
+			Size:      9,
+			Alignment: domain.AlignLeft,
+			Borders:   domain.Borders{Top: 1, Right: 1, Bottom: 1, Left: 1},
+		},
 	}
 }
 
+// FromStyle creates a PropsBuilder starting from an existing Style.
+func FromStyle(style domain.Style) *PropsBuilder {
+	return &PropsBuilder{style: style}
+}
+
 // WithFont sets the font name.
 func (b *PropsBuilder) WithFont(font string) *PropsBuilder {
-	b.font = font
+	b.style.Font = font
 	return b
 }
 
 // WithSize sets the font size.
 func (b *PropsBuilder) WithSize(size int) *PropsBuilder {
-	b.size = size
+	b.style.Size = size
 	return b
 }
 
 // Bold sets the font weight to bold.
 func (b *PropsBuilder) Bold() *PropsBuilder {
-	b.weight = "100"
+	b.style.Bold, b.style.Italic = true, false
 	return b
 }
 
 // Italic sets the font style to italic.
 func (b *PropsBuilder) Italic() *PropsBuilder {
-	b.weight = "010"
+	b.style.Bold, b.style.Italic = false, true
 	return b
 }
 
 // BoldItalic sets the font to bold and italic.
 func (b *PropsBuilder) BoldItalic() *PropsBuilder {
-	b.weight = "110"
+	b.style.Bold, b.style.Italic = true, true
+	return b
+}
+
+// Underline underlines the text in addition to the current weight.
+func (b *PropsBuilder) Underline() *PropsBuilder {
+	b.style.Underline = true
 	return b
 }
 
 // Normal sets the font weight to normal.
 func (b *PropsBuilder) Normal() *PropsBuilder {
-	b.weight = "000"
+	b.style.Bold, b.style.Italic, b.style.Underline = false, false, false
 	return b
 }
 
 // WithAlignment sets the text alignment.
 func (b *PropsBuilder) WithAlignment(alignment domain.Alignment) *PropsBuilder {
-	b.alignment = string(alignment)
+	b.style.Alignment = alignment
 	return b
 }
 
 // Left sets left alignment.
 func (b *PropsBuilder) Left() *PropsBuilder {
-	b.alignment = "left"
+	b.style.Alignment = domain.AlignLeft
 	return b
 }
 
 // Center sets center alignment.
 func (b *PropsBuilder) Center() *PropsBuilder {
-	b.alignment = "center"
+	b.style.Alignment = domain.AlignCenter
 	return b
 }
 
 // Right sets right alignment.
 func (b *PropsBuilder) Right() *PropsBuilder {
-	b.alignment = "right"
+	b.style.Alignment = domain.AlignRight
+	return b
+}
+
+// Justify sets justified alignment.
+func (b *PropsBuilder) Justify() *PropsBuilder {
+	b.style.Alignment = domain.AlignJustify
 	return b
 }
 
 // WithBorders sets all borders.
 func (b *PropsBuilder) WithBorders(top, right, bottom, left int) *PropsBuilder {
-	b.borders = [4]int{top, right, bottom, left}
+	b.style.Borders = domain.Borders{Top: top, Right: right, Bottom: bottom, Left: left}
 	return b
 }
 
 // NoBorders removes all borders.
 func (b *PropsBuilder) NoBorders() *PropsBuilder {
-	b.borders = [4]int{0, 0, 0, 0}
+	b.style.Borders = domain.Borders{}
 	return b
 }
 
 // AllBorders sets all borders to 1.
 func (b *PropsBuilder) AllBorders() *PropsBuilder {
-	b.borders = [4]int{1, 1, 1, 1}
+	b.style.Borders = domain.Borders{Top: 1, Right: 1, Bottom: 1, Left: 1}
 	return b
 }
 
+// WithBackground sets the background color of the style. Hex ("#EEE"), RGB
+// ("rgb(240, 240, 240)", "240,240,240") and named colors are accepted.
+// Colors are not part of the props string; they apply through BuildStyle.
+func (b *PropsBuilder) WithBackground(color string) *PropsBuilder {
+	return b.withColor(&b.style.Background, "background", color)
+}
+
+// WithTextColor sets the text color of the style.
+func (b *PropsBuilder) WithTextColor(color string) *PropsBuilder {
+	return b.withColor(&b.style.TextColor, "text color", color)
+}
+
+// WithBorderColor sets the border color of the style.
+func (b *PropsBuilder) WithBorderColor(color string) *PropsBuilder {
+	return b.withColor(&b.style.BorderColor, "border color", color)
+}
+
+// withColor stores color as "#RRGGBB" in dst, or records the first color
+// that cannot be parsed and leaves dst unchanged.
+func (b *PropsBuilder) withColor(dst *domain.Color, what, color string) *PropsBuilder {
+	c, err := domain.ParseColor(color)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("%s: %w", what, err)
+		}
+		return b
+	}
+	*dst = c
+	return b
+}
+
+// Err returns the first error of a color setter whose color could not be
+// parsed.
+func (b *PropsBuilder) Err() error {
+	return b.err
+}
+
 // Build returns the final props string.
 func (b *PropsBuilder) Build() string {
-	return fmt.Sprintf("%s:%d:%s:%s:%d:%d:%d:%d",
-		b.font, b.size, b.weight, b.alignment,
-		b.borders[0], b.borders[1], b.borders[2], b.borders[3])
+	return b.style.String()
+}
+
+// BuildStyle returns the final typed Style.
+func (b *PropsBuilder) BuildStyle() domain.Style {
+	return b.style
 }
@@ -2,15 +2,19 @@
 package builder
 
 import (
+	"fmt"
 	"sync"
 
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/barcode"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 )
 
 // documentBuilder implements the DocumentBuilder interface with fluent API.
 type documentBuilder struct {
-	doc *domain.Document
-	mu  sync.Mutex
+	doc        *domain.Document
+	err        error
+	tabIndexes map[int]string // field name by tab index
+	mu         sync.Mutex
 }
 
 // NewDocumentBuilder creates a new DocumentBuilder instance.
@@ -44,15 +48,121 @@ func (b *documentBuilder) WithTitle(props, text string) domain.DocumentBuilder {
 func (b *documentBuilder) WithTitleTable(table domain.Table) domain.DocumentBuilder {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.checkTabIndexes(table)
 	b.doc.Title.Table = &table
 	return b
 }
 
-// AddTable adds a table to the document.
+// AddTable adds a table to the document. Every table goes in
+// Document.Tables; once the body holds other blocks, a TableRef places the
+// table after them.
 func (b *documentBuilder) AddTable(table domain.Table) domain.DocumentBuilder {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.checkTabIndexes(table)
 	b.doc.Tables = append(b.doc.Tables, table)
+	if len(b.doc.Body) > 0 {
+		b.doc.Body = append(b.doc.Body, domain.TableRef{Index: len(b.doc.Tables) - 1})
+	}
+	return b
+}
+
+// addBlock appends a block other than a table to the body. The first one
+// places the tables added so far with TableRef blocks, so that they keep
+// coming before it.
+func (b *documentBuilder) addBlock(block domain.Block) {
+	if len(b.doc.Body) == 0 {
+		for i := range b.doc.Tables {
+			b.doc.Body = append(b.doc.Body, domain.TableRef{Index: i})
+		}
+	}
+	b.doc.Body = append(b.doc.Body, block)
+}
+
+// placeTables returns a copy of blocks with every table, including those in
+// nested groups, moved to Document.Tables and replaced by a TableRef.
+func (b *documentBuilder) placeTables(blocks []domain.Block) []domain.Block {
+	out := make([]domain.Block, len(blocks))
+	for i, block := range blocks {
+		switch v := block.(type) {
+		case domain.Table:
+			b.checkTabIndexes(v)
+			b.doc.Tables = append(b.doc.Tables, v)
+			block = domain.TableRef{Index: len(b.doc.Tables) - 1}
+		case *domain.Table:
+			if v != nil {
+				b.checkTabIndexes(*v)
+				b.doc.Tables = append(b.doc.Tables, *v)
+				block = domain.TableRef{Index: len(b.doc.Tables) - 1}
+			}
+		case domain.Group:
+			block = domain.Group{Blocks: b.placeTables(v.Blocks)}
+		case *domain.Group:
+			if v != nil {
+				block = domain.Group{Blocks: b.placeTables(v.Blocks)}
+			}
+		}
+		out[i] = block
+	}
+	return out
+}
+
+// AddPageBreak starts a new page before the next block.
+func (b *documentBuilder) AddPageBreak() domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(domain.PageBreak{})
+	return b
+}
+
+// AddSpacer adds vertical space before the next block.
+func (b *documentBuilder) AddSpacer(height domain.Length) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(domain.Spacer{Height: height})
+	return b
+}
+
+// AddGroup adds blocks that are kept on the same page. Its tables go in
+// Document.Tables like those of AddTable.
+func (b *documentBuilder) AddGroup(blocks ...domain.Block) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(domain.Group{}) // places the earlier tables before those of the group
+	b.doc.Body[len(b.doc.Body)-1] = domain.Group{Blocks: b.placeTables(blocks)}
+	return b
+}
+
+// AddParagraph adds a paragraph of wrapped text.
+func (b *documentBuilder) AddParagraph(props, text string) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(domain.Paragraph{Props: props, Text: text})
+	return b
+}
+
+// AddHeading adds a heading of the given level, 1 to 4.
+func (b *documentBuilder) AddHeading(level int, text string) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(domain.Heading{Level: level, Text: text})
+	return b
+}
+
+// AddList adds a bulleted or numbered list.
+func (b *documentBuilder) AddList(list domain.List) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(list)
+	return b
+}
+
+// AddTableOfContents adds a table of contents of the headings and
+// bookmarked tables of the document.
+func (b *documentBuilder) AddTableOfContents(toc domain.TableOfContents) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addBlock(toc)
 	return b
 }
 
@@ -64,17 +174,118 @@ func (b *documentBuilder) AddImage(image domain.Image) domain.DocumentBuilder {
 	return b
 }
 
-// WithFooter sets the document footer.
-func (b *documentBuilder) WithFooter(font, text string) domain.DocumentBuilder {
+// AddShape adds a line, rectangle or ellipse at a fixed position.
+func (b *documentBuilder) AddShape(shape domain.Shape) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Shapes = append(b.doc.Shapes, shape)
+	return b
+}
+
+// AddTextBox adds text at a fixed position.
+func (b *documentBuilder) AddTextBox(box domain.TextBox) domain.DocumentBuilder {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	b.doc.Footer = domain.Footer{
-		Font: font,
-		Text: text,
+	b.doc.TextBoxes = append(b.doc.TextBoxes, box)
+	return b
+}
+
+// AddQRCode adds a QR code image of the given size at a fixed position on
+// the first page. A zero size draws one point per module. Data that cannot
+// be encoded is not added and is reported by Err.
+func (b *documentBuilder) AddQRCode(x, y, size domain.Length, data string, opts domain.QROptions) domain.DocumentBuilder {
+	options, err := QROptions(opts)
+	var img domain.Image
+	if err == nil {
+		img, err = BarcodeImage(barcode.QR, data, options...)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
 	}
+	img.X, img.Y = x, y
+	if size > 0 {
+		img.Width, img.Height = size, size
+	}
+	b.doc.Images = append(b.doc.Images, img)
+	return b
+}
+
+// WithMetadata sets the document information such as author and keywords.
+func (b *documentBuilder) WithMetadata(metadata domain.Metadata) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Metadata = &metadata
 	return b
 }
 
+// WithHeader sets the page header.
+func (b *documentBuilder) WithHeader(header domain.Header) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Header = &header
+	return b
+}
+
+// WithFooter sets the document footer font and text, keeping its slots.
+func (b *documentBuilder) WithFooter(font, text string) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Footer.Font = font
+	b.doc.Footer.Text = text
+	return b
+}
+
+// WithPageFooter sets the whole footer, including its slots.
+func (b *documentBuilder) WithPageFooter(footer domain.Footer) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Footer = footer
+	return b
+}
+
+// AddBookmark adds an entry to the document outline.
+func (b *documentBuilder) AddBookmark(bookmark domain.Bookmark) domain.DocumentBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc.Bookmarks = append(b.doc.Bookmarks, bookmark)
+	return b
+}
+
+// checkTabIndexes records an error for the first form field of table whose
+// positive tab index an earlier field already uses.
+func (b *documentBuilder) checkTabIndexes(table domain.Table) {
+	for _, row := range table.Rows {
+		for _, cell := range row.Cells {
+			f := cell.FormField
+			if f == nil || f.TabIndex <= 0 {
+				continue
+			}
+			if first, ok := b.tabIndexes[f.TabIndex]; ok {
+				if b.err == nil {
+					b.err = fmt.Errorf("field %q: tab index %d is already used by field %q", f.Name, f.TabIndex, first)
+				}
+				continue
+			}
+			if b.tabIndexes == nil {
+				b.tabIndexes = make(map[int]string)
+			}
+			b.tabIndexes[f.TabIndex] = f.Name
+		}
+	}
+}
+
+// Err returns the first error of a method that could not add its content.
+func (b *documentBuilder) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
 // Build constructs and returns the final document.
 func (b *documentBuilder) Build() *domain.Document {
 	b.mu.Lock()
@@ -87,8 +298,28 @@ func (b *documentBuilder) Build() *domain.Document {
 func (b *documentBuilder) Reset() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.err = nil
+	b.tabIndexes = nil
 	b.doc = &domain.Document{
 		Tables: make([]domain.Table, 0),
 		Images: make([]domain.Image, 0),
 	}
 }
+
+// BulletList creates a bulleted list of plain items.
+func BulletList(items ...string) domain.List {
+	return listOf(false, items)
+}
+
+// NumberedList creates a numbered list of plain items.
+func NumberedList(items ...string) domain.List {
+	return listOf(true, items)
+}
+
+func listOf(ordered bool, items []string) domain.List {
+	list := domain.List{Ordered: ordered, Items: make([]domain.ListItem, len(items))}
+	for i, text := range items {
+		list.Items[i].Text = text
+	}
+	return list
+}
@@ -0,0 +1,77 @@
+// Package builder provides image loading helpers.
+package builder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// MaxImageSize is the largest image ImageFromURL downloads, in bytes.
+const MaxImageSize = 20 << 20
+
+// imageClient downloads images when ImageFromURL is given no client.
+var imageClient = &http.Client{Timeout: 30 * time.Second}
+
+// ImageFromFile reads a PNG, JPEG or GIF file into an inline image.
+func ImageFromFile(path string) (domain.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.Image{}, fmt.Errorf("%w: %s", domain.ErrFileNotFound, path)
+		}
+		return domain.Image{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	return domain.NewImage(data)
+}
+
+// ImageFromReader reads PNG, JPEG or GIF data into an inline image.
+func ImageFromReader(r io.Reader) (domain.Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	return domain.NewImage(data)
+}
+
+// ImageFromURL downloads a PNG, JPEG or GIF image of at most MaxImageSize
+// bytes into an inline image with client, or with a client that times out
+// after 30 seconds when client is nil. A data URI is decoded without a
+// request.
+func ImageFromURL(ctx context.Context, client *http.Client, url string) (domain.Image, error) {
+	if strings.HasPrefix(url, "data:") {
+		return domain.ParseDataURI(url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("%w: %v", domain.ErrHTTPRequest, err)
+	}
+	if client == nil {
+		client = imageClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("%w: %v", domain.ErrHTTPRequest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Image{}, domain.NewHTTPError(resp.StatusCode,
+			fmt.Sprintf("failed to download image: %s", resp.Status), domain.ErrHTTPRequest)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageSize+1))
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > MaxImageSize {
+		return domain.Image{}, fmt.Errorf("%w: larger than %d bytes", domain.ErrInvalidImage, MaxImageSize)
+	}
+	return domain.NewImage(data)
+}
@@ -0,0 +1,123 @@
+// Package builder provides the inline markup parser for rich text cells.
+package builder
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// linkColor is the text color of links created from markup.
+const linkColor domain.Color = "#0000FF"
+
+// Markup parses lightweight inline markup into text runs styled from base:
+// **bold**, _italic_ and [text](url) links, which are underlined and blue.
+// A backslash escapes the next character and unmatched markers are kept as
+// text. Runs without markup have a nil Style and inherit the cell style.
+func Markup(base domain.Style, markup string) []domain.TextRun {
+	p := markupParser{base: base}
+	s := markup
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			_, size := utf8.DecodeRuneInString(s[i+1:])
+			p.text.WriteString(s[i+1 : i+1+size])
+			i += 1 + size
+		case strings.HasPrefix(s[i:], "**") && (p.bold || strings.Contains(s[i+2:], "**")):
+			p.flush("")
+			p.bold = !p.bold
+			i += 2
+		case s[i] == '_' && p.italicMarker(s, i):
+			p.flush("")
+			p.italic = !p.italic
+			i++
+		case s[i] == '[':
+			label, url, n := parseLink(s[i:])
+			if n == 0 {
+				p.text.WriteByte('[')
+				i++
+				continue
+			}
+			p.flush("")
+			p.text.WriteString(label)
+			p.flush(url)
+			i += n
+		default:
+			p.text.WriteByte(s[i])
+			i++
+		}
+	}
+	p.flush("")
+	return p.runs
+}
+
+// markupParser accumulates the runs of a markup string.
+type markupParser struct {
+	base   domain.Style
+	bold   bool
+	italic bool
+	text   strings.Builder
+	runs   []domain.TextRun
+}
+
+// flush ends the current run, linking it to url when set.
+func (p *markupParser) flush(url string) {
+	if p.text.Len() == 0 {
+		return
+	}
+	run := domain.TextRun{Text: p.text.String(), Link: url}
+	p.text.Reset()
+	if p.bold || p.italic || url != "" {
+		style := p.base
+		style.Bold = style.Bold || p.bold
+		style.Italic = style.Italic || p.italic
+		if url != "" {
+			style.Underline = true
+			style.TextColor = linkColor
+		}
+		run.Style = &style
+	}
+	p.runs = append(p.runs, run)
+}
+
+// italicMarker reports whether the underscore at s[i] opens or closes
+// italics. Underscores inside words, as in snake_case, are text.
+func (p *markupParser) italicMarker(s string, i int) bool {
+	if p.italic {
+		return i > 0 && s[i-1] != ' ' && (i+1 == len(s) || !isWordByte(s[i+1]))
+	}
+	if (i > 0 && isWordByte(s[i-1])) || i+1 == len(s) || s[i+1] == ' ' {
+		return false
+	}
+	for j := i + 2; j < len(s); j++ {
+		if s[j] == '_' && s[j-1] != ' ' && (j+1 == len(s) || !isWordByte(s[j+1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordByte reports whether b is part of a word: a letter, a digit or a
+// byte of a multi-byte character.
+func isWordByte(b byte) bool {
+	return b >= 0x80 || b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// parseLink parses "[label](url)" at the start of s and returns its parts
+// and length, or a zero length when s does not start with a link.
+func parseLink(s string) (label, url string, n int) {
+	end := strings.Index(s, "](")
+	if end < 0 || strings.ContainsAny(s[1:end], "[]") {
+		return "", "", 0
+	}
+	close := strings.IndexByte(s[end+2:], ')')
+	if close < 0 {
+		return "", "", 0
+	}
+	url = strings.TrimSpace(s[end+2 : end+2+close])
+	if end == 1 || url == "" {
+		return "", "", 0
+	}
+	return s[1:end], url, end + 3 + close
+}
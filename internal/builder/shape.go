@@ -0,0 +1,30 @@
+package builder
+
+import "github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+
+// Line creates a line from (x1, y1) to (x2, y2), measured from the top-left
+// corner of the page.
+func Line(x1, y1, x2, y2 domain.Length) domain.Shape {
+	return domain.Shape{Type: domain.ShapeLine, X: x1, Y: y1, Width: x2 - x1, Height: y2 - y1}
+}
+
+// Rect creates a rectangle whose top-left corner is at (x, y).
+func Rect(x, y, width, height domain.Length) domain.Shape {
+	return domain.Shape{Type: domain.ShapeRect, X: x, Y: y, Width: width, Height: height}
+}
+
+// RoundRect creates a rectangle with corners rounded by radius.
+func RoundRect(x, y, width, height, radius domain.Length) domain.Shape {
+	return domain.Shape{Type: domain.ShapeRoundRect, X: x, Y: y, Width: width, Height: height, Radius: radius}
+}
+
+// Ellipse creates an ellipse inscribed in the rectangle whose top-left
+// corner is at (x, y).
+func Ellipse(x, y, width, height domain.Length) domain.Shape {
+	return domain.Shape{Type: domain.ShapeEllipse, X: x, Y: y, Width: width, Height: height}
+}
+
+// TextAt creates a text box at (x, y) sized to fit its text.
+func TextAt(x, y domain.Length, props, text string) domain.TextBox {
+	return domain.TextBox{X: x, Y: y, Props: props, Text: text}
+}
@@ -2,6 +2,8 @@
 package builder
 
 import (
+	"math"
+
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 )
 
@@ -35,6 +37,39 @@ func (b *tableBuilder) AddRow(cells ...domain.Cell) domain.TableBuilder {
 	return b
 }
 
+// AddHeaderRow adds a header row, repeated at the top of every page the
+// table continues on. Header rows stay ahead of the body rows regardless of
+// the order they are added in.
+func (b *tableBuilder) AddHeaderRow(cells ...domain.Cell) domain.TableBuilder {
+	n := b.table.HeaderRows
+	b.table.Rows = append(b.table.Rows, domain.Row{})
+	copy(b.table.Rows[n+1:], b.table.Rows[n:])
+	b.table.Rows[n] = domain.Row{Cells: cells}
+	b.table.HeaderRows++
+	return b
+}
+
+// KeepRowsTogether moves rows that do not fit on a page to the next one
+// instead of splitting them. Rows taller than a page are still split.
+func (b *tableBuilder) KeepRowsTogether() domain.TableBuilder {
+	b.table.KeepRowsTogether = true
+	return b
+}
+
+// KeepWithPrevious keeps the start of the table on the same page as the
+// preceding table.
+func (b *tableBuilder) KeepWithPrevious() domain.TableBuilder {
+	b.table.KeepWithPrevious = true
+	return b
+}
+
+// WithAnchor names the position of the table as a target of internal links
+// and bookmarks.
+func (b *tableBuilder) WithAnchor(name string) domain.TableBuilder {
+	b.table.Anchor = name
+	return b
+}
+
 // AddRowWithHeight adds a row with custom height.
 func (b *tableBuilder) AddRowWithHeight(height int, cells ...domain.Cell) domain.TableBuilder {
 	row := domain.Row{
@@ -45,6 +80,28 @@ func (b *tableBuilder) AddRowWithHeight(height int, cells ...domain.Cell) domain
 	return b
 }
 
+// AddRowWithLength adds a row with a custom height in any unit, rounded to
+// whole points.
+func (b *tableBuilder) AddRowWithLength(height domain.Length, cells ...domain.Cell) domain.TableBuilder {
+	return b.AddRowWithHeight(int(math.Round(height.Points())), cells...)
+}
+
+// AddSpannedRow adds a row whose cells span the given number of columns.
+// colspans[i] applies to cells[i]; cells without an entry keep their own span.
+func (b *tableBuilder) AddSpannedRow(colspans []int, cells ...domain.Cell) domain.TableBuilder {
+	row := domain.Row{
+		Cells: make([]domain.Cell, len(cells)),
+	}
+	copy(row.Cells, cells)
+	for i, span := range colspans {
+		if i < len(row.Cells) {
+			row.Cells[i].ColSpan = span
+		}
+	}
+	b.table.Rows = append(b.table.Rows, row)
+	return b
+}
+
 // Build constructs and returns the final table.
 func (b *tableBuilder) Build() domain.Table {
 	return b.table
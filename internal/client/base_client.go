@@ -24,7 +24,7 @@ func NewBaseClient(client *http.Client, headers map[string]string) *BaseClient {
 }
 
 // Do executes the HTTP request using the underlying http.Client.
-func (c *BaseClient) Do(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+func (c *BaseClient) Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -33,14 +33,8 @@ func (c *BaseClient) Do(ctx context.Context, method, url string, body io.Reader)
 	for k, v := range c.headers {
 		req.Header.Set(k, v)
 	}
-	// Content-Type for JSON is usually set by the caller (Post) or we can set it here if we know.
-	// But `Post` in `Client` sets it. Wait, `Client.Post` calls `Do`.
-	// `Client.Post` sets `Content-Type` on the request? No, it can't access the request.
-	// `Client.Post` in the OLD code set it on the request.
-
-	// We need to handle Content-Type.
-	if method == http.MethodPost {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	resp, err := c.client.Do(req)
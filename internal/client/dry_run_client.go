@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// DryRunDirEnv is the environment variable that enables dry-run mode.
+// When set, payloads are written to the named directory instead of being sent.
+const DryRunDirEnv = "GOPDFSUIT_DRY_RUN_DIR"
+
+// DryRunClient is the innermost HTTPClient used in dry-run mode. Instead of
+// executing requests it writes their payloads to a directory and returns a
+// placeholder PDF, so every decorator above it behaves as for a real send.
+type DryRunClient struct {
+	dir string
+	now func() time.Time
+}
+
+// NewDryRunClient creates a new DryRunClient writing payloads to dir.
+func NewDryRunClient(dir string) *DryRunClient {
+	return &DryRunClient{
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// Do writes the request body to the dry-run directory and returns a placeholder PDF.
+// JSON payloads are canonicalized and pretty-printed; other payloads are written
+// as-is, with their content type on the placeholder page.
+func (c *DryRunClient) Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	payload, ext := data, ".bin"
+	if contentType == "application/json" {
+		payload, ext = canonicalPayload(data)
+	}
+	sum := sha256.Sum256(payload)
+	name := fmt.Sprintf("%s-%s%s", c.now().UTC().Format("20060102T150405.000Z"), hex.EncodeToString(sum[:])[:12], ext)
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create dry-run directory: %w", err)
+	}
+	path := filepath.Join(c.dir, name)
+	if err := os.WriteFile(path, payload, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write dry-run payload: %w", err)
+	}
+
+	lines := []string{fmt.Sprintf("Dry run: %s %s", method, url), "Payload written to " + path}
+	if ext == ".bin" && contentType != "" {
+		lines = append(lines, "Content-Type: "+contentType)
+	}
+	return placeholderPDF(lines...), nil
+}
+
+// Post is not implemented in DryRunClient as it's a convenience method.
+func (c *DryRunClient) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// Get is not implemented in DryRunClient as it's a convenience method.
+func (c *DryRunClient) Get(ctx context.Context, url string) ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// secretKeys are the JSON keys whose values are replaced by redactedValue
+// before a payload is written to disk.
+var secretKeys = map[string]bool{
+	"userPassword":  true,
+	"ownerPassword": true,
+}
+
+// redactedValue replaces secret values in dry-run payloads.
+const redactedValue = "[REDACTED]"
+
+// canonicalPayload re-encodes JSON with sorted keys and indentation so that
+// equal documents produce identical files, with the values of secretKeys
+// redacted. Non-JSON data is returned unchanged.
+func canonicalPayload(data []byte) ([]byte, string) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return data, ".bin"
+	}
+	redact(v)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return data, ".bin"
+	}
+	return buf.Bytes(), ".json"
+}
+
+// redact replaces the non-empty values of secretKeys anywhere in v.
+func redact(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if s, ok := val.(string); ok && s != "" && secretKeys[key] {
+				v[key] = redactedValue
+				continue
+			}
+			redact(val)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redact(item)
+		}
+	}
+}
+
+// placeholderPDF builds a one-page PDF showing the given lines of text.
+func placeholderPDF(lines ...string) []byte {
+	var content bytes.Buffer
+	content.WriteString("BT /F1 12 Tf 72 770 Td 16 TL\n")
+	for _, line := range lines {
+		content.WriteString(pdf.Literal([]byte(line)))
+		content.WriteString(" '\n")
+	}
+	content.WriteString("ET\n")
+
+	w := pdf.NewWriter()
+	pages := w.Alloc()
+	font := w.Add(pdf.Dict{
+		"Type":     pdf.Name("Font"),
+		"Subtype":  pdf.Name("Type1"),
+		"BaseFont": pdf.Name("Helvetica"),
+	})
+	page := w.Add(pdf.Dict{
+		"Type":      pdf.Name("Page"),
+		"Parent":    pages,
+		"MediaBox":  pdf.Array{0, 0, 612, 792},
+		"Resources": pdf.Dict{"Font": pdf.Dict{"F1": font}},
+		"Contents":  w.Add(&pdf.Stream{Data: content.Bytes()}),
+	})
+	w.Set(pages, pdf.Dict{"Type": pdf.Name("Pages"), "Kids": pdf.Array{page}, "Count": 1})
+	root := w.Add(pdf.Dict{"Type": pdf.Name("Catalog"), "Pages": pages})
+	return w.Bytes(pdf.Dict{"Root": root})
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// placeholderText returns the content stream of the placeholder page.
+func placeholderText(t *testing.T, data []byte) string {
+	t.Helper()
+	r, err := pdf.NewReader(data)
+	if err != nil {
+		t.Fatalf("parsing the placeholder: %v", err)
+	}
+	cat, err := r.ResolveDict(r.Trailer()["Root"])
+	if err != nil {
+		t.Fatalf("catalog: %v", err)
+	}
+	pages, err := r.ResolveDict(cat["Pages"])
+	if err != nil {
+		t.Fatalf("page tree: %v", err)
+	}
+	kids, err := r.ResolveArray(pages["Kids"])
+	if err != nil || len(kids) != 1 {
+		t.Fatalf("page tree kids = %v, %v; want one page", kids, err)
+	}
+	page, err := r.ResolveDict(kids[0])
+	if err != nil {
+		t.Fatalf("page: %v", err)
+	}
+	obj, err := r.Resolve(page["Contents"])
+	if err != nil {
+		t.Fatalf("contents: %v", err)
+	}
+	s, ok := obj.(*pdf.Stream)
+	if !ok {
+		t.Fatalf("contents is %T, want a stream", obj)
+	}
+	content, err := pdf.DecodeStream(s)
+	if err != nil {
+		t.Fatalf("contents: %v", err)
+	}
+	return string(content)
+}
+
+func TestDryRunClient(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 45, 123e6, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string // the file written
+		wantExt     string
+		wantLine    string // a line of the placeholder page besides the path
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body: `{"title":{"text":"Intake"},"config":{"page":"A4","security":{"ownerPassword":"owner-secret",` +
+				`"userPassword":"","enabled":true},"pageAlignment":1.50},` +
+				`"table":[{"userPassword":"user-secret","note":"<b>&</b>"}]}`,
+			want: `{
+  "config": {
+    "page": "A4",
+    "pageAlignment": 1.50,
+    "security": {
+      "enabled": true,
+      "ownerPassword": "[REDACTED]",
+      "userPassword": ""
+    }
+  },
+  "table": [
+    {
+      "note": "<b>&</b>",
+      "userPassword": "[REDACTED]"
+    }
+  ],
+  "title": {
+    "text": "Intake"
+  }
+}
+`,
+			wantExt: ".json",
+		},
+		{
+			name:        "multipart",
+			contentType: "multipart/form-data; boundary=x",
+			body:        "--x\r\nContent-Disposition: form-data; name=\"pdf\"\r\n\r\n%PDF\r\n--x--\r\n",
+			want:        "--x\r\nContent-Disposition: form-data; name=\"pdf\"\r\n\r\n%PDF\r\n--x--\r\n",
+			wantExt:     ".bin",
+			wantLine:    "Content-Type: multipart/form-data; boundary=x",
+		},
+		{
+			name:        "invalid JSON",
+			contentType: "application/json",
+			body:        `{"userPassword":`,
+			want:        `{"userPassword":`,
+			wantExt:     ".bin",
+			wantLine:    "Content-Type: application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "dry")
+			c := NewDryRunClient(dir)
+			c.now = func() time.Time { return now }
+
+			out, err := c.Do(context.Background(), "POST", "http://pdf.test/api/v1/generate/template-pdf", tt.contentType, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+
+			sum := sha256.Sum256([]byte(tt.want))
+			name := "20240501T103045.123Z-" + hex.EncodeToString(sum[:])[:12] + tt.wantExt
+			path := filepath.Join(dir, name)
+			written, err := os.ReadFile(path)
+			if err != nil {
+				entries, _ := os.ReadDir(dir)
+				t.Fatalf("reading %s: %v (directory holds %v)", name, err, entries)
+			}
+			if string(written) != tt.want {
+				t.Errorf("payload file\n%s\nwant\n%s", written, tt.want)
+			}
+
+			text := placeholderText(t, out)
+			lines := []string{"Dry run: POST http://pdf.test/api/v1/generate/template-pdf", "Payload written to " + path}
+			if tt.wantLine != "" {
+				lines = append(lines, tt.wantLine)
+			}
+			for _, line := range lines {
+				if !strings.Contains(text, pdf.Literal([]byte(line))) {
+					t.Errorf("placeholder does not show %q:\n%s", line, text)
+				}
+			}
+			if tt.wantLine == "" && strings.Contains(text, "Content-Type") {
+				t.Errorf("placeholder shows a content type for a canonical JSON payload:\n%s", text)
+			}
+		})
+	}
+}
+
+func TestDryRunClientCanonical(t *testing.T) {
+	dir := t.TempDir()
+	c := NewDryRunClient(dir)
+	c.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	for _, body := range []string{
+		`{"b":1,"a":{"ownerPassword":"first"}}`,
+		`{ "a": { "ownerPassword": "second" }, "b": 1 }`,
+	} {
+		if _, err := c.Do(context.Background(), "POST", "/", "application/json", strings.NewReader(body)); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("%d files for equal payloads with different secrets, want 1: %v", len(entries), entries)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("first")) || bytes.Contains(data, []byte("second")) {
+		t.Errorf("payload file leaks a password:\n%s", data)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Do(ctx, "POST", "/", "application/json", strings.NewReader(`{}`)); err == nil {
+		t.Error("Do with a canceled context succeeded")
+	}
+}
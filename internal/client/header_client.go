@@ -29,7 +29,7 @@ func NewHeaderClient(next domain.HTTPClient, headers map[string]string) *HeaderC
 // Or `Do` takes `*http.Request`.
 //
 // Let's modify `BaseClient` to take headers.
-func (c *HeaderClient) Do(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+func (c *HeaderClient) Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error) {
 	// This decorator pattern breaks if we can't modify the request.
 	// But wait, `BaseClient` is the one creating the request.
 	// If we want to inject headers, we should do it BEFORE BaseClient executes.
@@ -39,7 +39,7 @@ func (c *HeaderClient) Do(ctx context.Context, method, url string, body io.Reade
 	// But the interface is `Do(..., method, url, body)`.
 
 	// Let's stick to modifying `BaseClient` to accept headers in its struct.
-	return c.next.Do(ctx, method, url, body)
+	return c.next.Do(ctx, method, url, contentType, body)
 }
 
 // Post delegates to Do.
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
@@ -22,15 +23,18 @@ type Config struct {
 	Headers     map[string]string
 	Logger      domain.Logger
 	RetryPolicy domain.RetryPolicy
+	DryRunDir   string
 }
 
 // DefaultConfig returns a default configuration.
+// Dry-run mode is enabled when the DryRunDirEnv environment variable is set.
 func DefaultConfig() *Config {
 	return &Config{
 		Timeout:    30 * time.Second,
 		MaxRetries: 3,
 		RetryDelay: time.Second,
 		Headers:    make(map[string]string),
+		DryRunDir:  os.Getenv(DryRunDirEnv),
 	}
 }
 
@@ -79,6 +83,14 @@ func WithRetryPolicy(policy domain.RetryPolicy) Option {
 	}
 }
 
+// WithDryRun writes request payloads to dir instead of sending them.
+// An empty dir disables dry-run mode.
+func WithDryRun(dir string) Option {
+	return func(c *Client) {
+		c.config.DryRunDir = dir
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(httpClient *http.Client) Option {
 	return func(c *Client) {
@@ -114,6 +126,9 @@ func New(baseURL string, opts ...Option) *Client {
 
 	// Build the decorator chain
 	var doer domain.HTTPClient = NewBaseClient(c.httpClient, c.config.Headers)
+	if c.config.DryRunDir != "" {
+		doer = NewDryRunClient(c.config.DryRunDir)
+	}
 
 	// Add retry decorator
 	if c.config.MaxRetries > 0 {
@@ -125,8 +140,8 @@ func New(baseURL string, opts ...Option) *Client {
 }
 
 // Do executes an HTTP request with retry logic.
-func (c *Client) Do(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
-	return c.doer.Do(ctx, method, url, body)
+func (c *Client) Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error) {
+	return c.doer.Do(ctx, method, url, contentType, body)
 }
 
 // Post sends a POST request with JSON body.
@@ -137,13 +152,19 @@ func (c *Client) Post(ctx context.Context, url string, body interface{}) ([]byte
 	}
 
 	fullURL := c.config.BaseURL + url
-	return c.Do(ctx, http.MethodPost, fullURL, bytes.NewReader(jsonBody))
+	return c.Do(ctx, http.MethodPost, fullURL, "application/json", bytes.NewReader(jsonBody))
+}
+
+// PostRaw sends a POST request with a pre-encoded body of the given content type.
+func (c *Client) PostRaw(ctx context.Context, url, contentType string, body io.Reader) ([]byte, error) {
+	fullURL := c.config.BaseURL + url
+	return c.Do(ctx, http.MethodPost, fullURL, contentType, body)
 }
 
 // Get sends a GET request.
 func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
 	fullURL := c.config.BaseURL + url
-	return c.Do(ctx, http.MethodGet, fullURL, nil)
+	return c.Do(ctx, http.MethodGet, fullURL, "", nil)
 }
 
 // doWithRetry is removed as it is replaced by RetryClient.
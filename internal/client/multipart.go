@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+)
+
+// Multipart field names expected by the gopdfsuit upload endpoints.
+const (
+	mergeFileField = "pdf_files"
+	fillPDFField   = "pdf"
+	fillXFDFField  = "xfdf"
+)
+
+// multipartForm builds an in-memory multipart/form-data body so that the
+// retry decorator can resend it.
+type multipartForm struct {
+	buf    bytes.Buffer
+	writer *multipart.Writer
+}
+
+func newMultipartForm() *multipartForm {
+	f := &multipartForm{}
+	f.writer = multipart.NewWriter(&f.buf)
+	return f
+}
+
+// addFile copies r into a file part with the given field and file name.
+func (f *multipartForm) addFile(field, name, contentType string, r io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, name))
+	header.Set("Content-Type", contentType)
+	part, err := f.writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create multipart part: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return nil
+}
+
+// finish closes the form and returns the body and its Content-Type.
+func (f *multipartForm) finish() (io.Reader, string, error) {
+	if err := f.writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to finalize multipart body: %w", err)
+	}
+	return &f.buf, f.writer.FormDataContentType(), nil
+}
+
+// fileName returns the base name of readers backed by a file, such as
+// *os.File, or a generated name based on the input position.
+func fileName(r io.Reader, index int) string {
+	if named, ok := r.(interface{ Name() string }); ok && named.Name() != "" {
+		return filepath.Base(named.Name())
+	}
+	return fmt.Sprintf("document%d.pdf", index+1)
+}
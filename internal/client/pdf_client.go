@@ -2,31 +2,85 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 )
 
+// Default endpoints of the gopdfsuit service.
+const (
+	DefaultGenerateEndpoint    = "/api/v1/generate/template-pdf"
+	DefaultMergeEndpoint       = "/api/v1/merge"
+	DefaultFillEndpoint        = "/api/v1/fill"
+	DefaultHTMLToPDFEndpoint   = "/api/v1/htmltopdf"
+	DefaultHTMLToImageEndpoint = "/api/v1/htmltoimage"
+)
+
 // PDFClient handles PDF document operations.
 type PDFClient struct {
-	httpClient *Client
-	endpoint   string
+	httpClient    *Client
+	endpoint      string
+	mergeEndpoint string
+	fillEndpoint  string
+	htmlEndpoints htmlEndpoints
+	skipValidate  bool
+}
+
+// htmlEndpoints holds the HTML conversion endpoints.
+type htmlEndpoints struct {
+	pdf   string
+	image string
 }
 
 // NewPDFClient creates a new PDFClient.
 func NewPDFClient(httpClient *Client, endpoint string) *PDFClient {
 	return &PDFClient{
-		httpClient: httpClient,
-		endpoint:   endpoint,
+		httpClient:    httpClient,
+		endpoint:      endpoint,
+		mergeEndpoint: DefaultMergeEndpoint,
+		fillEndpoint:  DefaultFillEndpoint,
+		htmlEndpoints: htmlEndpoints{
+			pdf:   DefaultHTMLToPDFEndpoint,
+			image: DefaultHTMLToImageEndpoint,
+		},
 	}
 }
 
+// SetMergeEndpoint updates the merge endpoint.
+func (c *PDFClient) SetMergeEndpoint(endpoint string) {
+	c.mergeEndpoint = endpoint
+}
+
+// SetFillEndpoint updates the form-fill endpoint.
+func (c *PDFClient) SetFillEndpoint(endpoint string) {
+	c.fillEndpoint = endpoint
+}
+
+// SetHTMLEndpoints updates the HTML-to-PDF and HTML-to-image endpoints.
+func (c *PDFClient) SetHTMLEndpoints(pdfEndpoint, imageEndpoint string) {
+	c.htmlEndpoints = htmlEndpoints{pdf: pdfEndpoint, image: imageEndpoint}
+}
+
+// SetValidation enables or disables document validation before sending.
+// Validation is enabled by default.
+func (c *PDFClient) SetValidation(enabled bool) {
+	c.skipValidate = !enabled
+}
+
 // Send sends a document to the PDF service and returns the response.
 func (c *PDFClient) Send(ctx context.Context, doc *domain.Document) ([]byte, error) {
 	if doc == nil {
 		return nil, domain.ErrDocumentNil
 	}
+	if !c.skipValidate {
+		if err := doc.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	return c.httpClient.Post(ctx, c.endpoint, doc)
 }
@@ -41,6 +95,80 @@ func (c *PDFClient) SendAndSave(ctx context.Context, doc *domain.Document, outpu
 	return saveToFile(outputPath, response)
 }
 
+// Merge uploads the PDFs to the merge endpoint and returns the merged PDF.
+// The inputs are merged in the order given.
+func (c *PDFClient) Merge(ctx context.Context, inputs ...io.Reader) ([]byte, error) {
+	if len(inputs) == 0 {
+		return nil, domain.ErrNoInputs
+	}
+
+	form := newMultipartForm()
+	for i, input := range inputs {
+		if err := form.addFile(mergeFileField, fileName(input, i), "application/pdf", input); err != nil {
+			return nil, err
+		}
+	}
+	body, contentType, err := form.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.httpClient.PostRaw(ctx, c.mergeEndpoint, contentType, body)
+}
+
+// Fill fills the AcroForm fields of an existing PDF with the given values and
+// returns the filled PDF. The values are sent as XFDF alongside the PDF.
+func (c *PDFClient) Fill(ctx context.Context, pdf io.Reader, values map[string][]string) ([]byte, error) {
+	if pdf == nil {
+		return nil, domain.ErrNoInputs
+	}
+	xfdf, err := BuildXFDF(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode XFDF: %w", err)
+	}
+
+	form := newMultipartForm()
+	if err := form.addFile(fillPDFField, fileName(pdf, 0), "application/pdf", pdf); err != nil {
+		return nil, err
+	}
+	if err := form.addFile(fillXFDFField, "data.xfdf", "application/vnd.adobe.xfdf", bytes.NewReader(xfdf)); err != nil {
+		return nil, err
+	}
+	body, contentType, err := form.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.httpClient.PostRaw(ctx, c.fillEndpoint, contentType, body)
+}
+
+// HTMLToPDF converts an HTML page to PDF.
+func (c *PDFClient) HTMLToPDF(ctx context.Context, req domain.HTMLDocument) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Post(ctx, c.htmlEndpoints.pdf, req)
+}
+
+// htmlImageRequest is the payload of the HTML-to-image endpoint, which takes
+// the output format next to the fields of the page.
+type htmlImageRequest struct {
+	domain.HTMLDocument
+	Format domain.ImageFormat `json:"format"`
+}
+
+// HTMLToImage converts an HTML page to an image in the given format.
+// An empty format defaults to PNG.
+func (c *PDFClient) HTMLToImage(ctx context.Context, req domain.HTMLDocument, format domain.ImageFormat) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = domain.ImageFormatPNG
+	}
+	return c.httpClient.Post(ctx, c.htmlEndpoints.image, htmlImageRequest{HTMLDocument: req, Format: format})
+}
+
 // saveToFile saves data to a file.
 func saveToFile(path string, data []byte) error {
 	return os.WriteFile(path, data, 0644)
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// request is a request received by a test server.
+type request struct {
+	path        string
+	contentType string
+	body        []byte
+}
+
+// testServer starts a server that records each request and answers with
+// response, and returns a PDFClient for it.
+func testServer(t *testing.T, response string) (*PDFClient, *[]request) {
+	t.Helper()
+	var received []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+		received = append(received, request{r.URL.Path, r.Header.Get("Content-Type"), body})
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	return NewPDFClient(New(srv.URL), DefaultGenerateEndpoint), &received
+}
+
+// part is a file part of a multipart request.
+type part struct {
+	field       string
+	fileName    string
+	contentType string
+	body        string
+}
+
+// parts parses the multipart/form-data body of req.
+func parts(t *testing.T, req request) []part {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(req.contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Content-Type = %q, want multipart/form-data", req.contentType)
+	}
+	r := multipart.NewReader(bytes.NewReader(req.body), params["boundary"])
+	var out []part
+	for {
+		p, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("reading multipart body: %v", err)
+		}
+		body, err := io.ReadAll(p)
+		if err != nil {
+			t.Fatalf("reading part %s: %v", p.FormName(), err)
+		}
+		out = append(out, part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(body)})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cover.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-cover"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	c, received := testServer(t, "merged")
+	out, err := c.Merge(context.Background(), file, strings.NewReader("%PDF-body"))
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if string(out) != "merged" {
+		t.Errorf("response = %q, want %q", out, "merged")
+	}
+	if len(*received) != 1 {
+		t.Fatalf("server received %d requests, want 1", len(*received))
+	}
+	req := (*received)[0]
+	if req.path != DefaultMergeEndpoint {
+		t.Errorf("path = %s, want %s", req.path, DefaultMergeEndpoint)
+	}
+	want := []part{
+		{"pdf_files", "cover.pdf", "application/pdf", "%PDF-cover"},
+		{"pdf_files", "document2.pdf", "application/pdf", "%PDF-body"},
+	}
+	if got := parts(t, req); !slices.Equal(got, want) {
+		t.Errorf("parts\n got %+v\nwant %+v", got, want)
+	}
+
+	if _, err := c.Merge(context.Background()); !errors.Is(err, domain.ErrNoInputs) {
+		t.Errorf("Merge without inputs: %v, want %v", err, domain.ErrNoInputs)
+	}
+	if len(*received) != 1 {
+		t.Errorf("Merge without inputs sent a request")
+	}
+}
+
+func TestFill(t *testing.T) {
+	c, received := testServer(t, "filled")
+	values := map[string][]string{
+		"first_name": {"Michael"},
+		"allergies":  {"Latex", "Pollen"},
+	}
+	out, err := c.Fill(context.Background(), strings.NewReader("%PDF-form"), values)
+	if err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if string(out) != "filled" {
+		t.Errorf("response = %q, want %q", out, "filled")
+	}
+	if len(*received) != 1 {
+		t.Fatalf("server received %d requests, want 1", len(*received))
+	}
+	req := (*received)[0]
+	if req.path != DefaultFillEndpoint {
+		t.Errorf("path = %s, want %s", req.path, DefaultFillEndpoint)
+	}
+	got := parts(t, req)
+	if len(got) != 2 {
+		t.Fatalf("%d parts, want 2: %+v", len(got), got)
+	}
+	if want := (part{"pdf", "document1.pdf", "application/pdf", "%PDF-form"}); got[0] != want {
+		t.Errorf("PDF part = %+v, want %+v", got[0], want)
+	}
+	xfdf := got[1]
+	if xfdf.field != "xfdf" || xfdf.fileName != "data.xfdf" || xfdf.contentType != "application/vnd.adobe.xfdf" {
+		t.Errorf("XFDF part = %q %q %q, want xfdf data.xfdf application/vnd.adobe.xfdf", xfdf.field, xfdf.fileName, xfdf.contentType)
+	}
+	var doc xfdfDocument
+	if err := xml.Unmarshal([]byte(xfdf.body), &doc); err != nil {
+		t.Fatalf("XFDF body %s: %v", xfdf.body, err)
+	}
+	sent := make(map[string][]string)
+	for _, f := range doc.Fields {
+		sent[f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(sent, values) {
+		t.Errorf("XFDF fields = %v, want %v\n%s", sent, values, xfdf.body)
+	}
+
+	if _, err := c.Fill(context.Background(), nil, values); !errors.Is(err, domain.ErrNoInputs) {
+		t.Errorf("Fill without a PDF: %v, want %v", err, domain.ErrNoInputs)
+	}
+}
+
+func TestHTMLPayloads(t *testing.T) {
+	page := domain.HTMLDocument{
+		HTML:        "<h1>Spring Campaign</h1>",
+		PageSize:    domain.PageSizeA4,
+		Orientation: domain.OrientationLandscape,
+		HTMLMargins: domain.HTMLMargins{Top: "10mm"},
+		Quality:     80,
+	}
+	tests := []struct {
+		name     string
+		send     func(*PDFClient) ([]byte, error)
+		wantPath string
+		want     map[string]any
+	}{
+		{
+			name:     "PDF",
+			send:     func(c *PDFClient) ([]byte, error) { return c.HTMLToPDF(context.Background(), page) },
+			wantPath: DefaultHTMLToPDFEndpoint,
+			want: map[string]any{"html": "<h1>Spring Campaign</h1>", "page_size": "A4", "orientation": "landscape",
+				"margin_top": "10mm", "quality": 80.0},
+		},
+		{
+			name: "image",
+			send: func(c *PDFClient) ([]byte, error) {
+				return c.HTMLToImage(context.Background(), page, domain.ImageFormatJPEG)
+			},
+			wantPath: DefaultHTMLToImageEndpoint,
+			want: map[string]any{"html": "<h1>Spring Campaign</h1>", "page_size": "A4", "orientation": "landscape",
+				"margin_top": "10mm", "quality": 80.0, "format": "jpg"},
+		},
+		{
+			name:     "image without format",
+			send:     func(c *PDFClient) ([]byte, error) { return c.HTMLToImage(context.Background(), page, "") },
+			wantPath: DefaultHTMLToImageEndpoint,
+			want: map[string]any{"html": "<h1>Spring Campaign</h1>", "page_size": "A4", "orientation": "landscape",
+				"margin_top": "10mm", "quality": 80.0, "format": "png"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, received := testServer(t, "result")
+			out, err := tt.send(c)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if string(out) != "result" {
+				t.Errorf("response = %q, want %q", out, "result")
+			}
+			if len(*received) != 1 {
+				t.Fatalf("server received %d requests, want 1", len(*received))
+			}
+			req := (*received)[0]
+			if req.path != tt.wantPath {
+				t.Errorf("path = %s, want %s", req.path, tt.wantPath)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(req.body, &got); err != nil {
+				t.Fatalf("payload %s: %v", req.body, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("payload = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("payload[%q] = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
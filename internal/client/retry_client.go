@@ -30,7 +30,7 @@ func NewRetryClient(next domain.HTTPClient, maxRetries int, retryDelay time.Dura
 }
 
 // Do executes the request with retries.
-func (c *RetryClient) Do(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+func (c *RetryClient) Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error) {
 	var lastErr error
 
 	// If body is an io.ReadCloser, we can't easily rewind it for retries unless we buffer it.
@@ -75,7 +75,7 @@ func (c *RetryClient) Do(ctx context.Context, method, url string, body io.Reader
 			currentBody = utils.NewBytesReader(bodyBytes)
 		}
 
-		resp, err := c.next.Do(ctx, method, url, currentBody)
+		resp, err := c.next.Do(ctx, method, url, contentType, currentBody)
 		if err == nil {
 			return resp, nil
 		}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// SigningSender decorates a DocumentSender to digitally sign the PDFs of
+// documents that request a signature in their configuration.
+type SigningSender struct {
+	next   domain.DocumentSender
+	signer domain.PDFSigner
+}
+
+// NewSigningSender creates a new SigningSender.
+func NewSigningSender(next domain.DocumentSender, signer domain.PDFSigner) *SigningSender {
+	return &SigningSender{
+		next:   next,
+		signer: signer,
+	}
+}
+
+// Send sends the document and signs the returned PDF when the document
+// configuration has a signature.
+func (s *SigningSender) Send(ctx context.Context, doc *domain.Document) ([]byte, error) {
+	out, err := s.next.Send(ctx, doc)
+	if err != nil || doc == nil || doc.Config.Signature == nil {
+		return out, err
+	}
+	signed, err := s.signer.Sign(out, *doc.Config.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign PDF: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+)
+
+// xfdfDocument is the XML structure of an XFDF form data file.
+type xfdfDocument struct {
+	XMLName xml.Name    `xml:"xfdf"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Space   string      `xml:"xml:space,attr"`
+	Fields  []xfdfField `xml:"fields>field"`
+}
+
+// xfdfField is a named field with one value, or several for a multi-select
+// list box.
+type xfdfField struct {
+	Name   string   `xml:"name,attr"`
+	Values []string `xml:"value"`
+}
+
+// BuildXFDF encodes field values as an XFDF document, with a value element
+// for each value of a field. Fields are written in name order so the output
+// is deterministic.
+func BuildXFDF(values map[string][]string) ([]byte, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	doc := xfdfDocument{
+		XMLNS: "http://ns.adobe.com/xfdf/",
+		Space: "preserve",
+	}
+	for _, name := range names {
+		doc.Fields = append(doc.Fields, xfdfField{Name: name, Values: values[name]})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestBuildXFDF(t *testing.T) {
+	values := map[string][]string{
+		"first_name": {"Michael & Co <Ltd>"},
+		"allergies":  {"Latex", "Pollen"},
+		"diet":       nil,
+	}
+	data, err := BuildXFDF(values)
+	if err != nil {
+		t.Fatalf("BuildXFDF: %v", err)
+	}
+
+	const want = xml.Header + `<xfdf xmlns="http://ns.adobe.com/xfdf/" xml:space="preserve">
+  <fields>
+    <field name="allergies">
+      <value>Latex</value>
+      <value>Pollen</value>
+    </field>
+    <field name="diet"></field>
+    <field name="first_name">
+      <value>Michael &amp; Co &lt;Ltd&gt;</value>
+    </field>
+  </fields>
+</xfdf>
+`
+	if string(data) != want {
+		t.Errorf("XFDF\n%s\nwant\n%s", data, want)
+	}
+
+	var back xfdfDocument
+	if err := xml.Unmarshal(data, &back); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	got := make(map[string][]string)
+	for _, f := range back.Fields {
+		got[f.Name] = f.Values
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Errorf("decoded %v, want %v", got, values)
+	}
+}
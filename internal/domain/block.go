@@ -0,0 +1,346 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BlockType identifies the kind of a body block in JSON.
+type BlockType string
+
+// Block types.
+const (
+	BlockTable     BlockType = "table"
+	BlockPageBreak BlockType = "pagebreak"
+	BlockSpacer    BlockType = "spacer"
+	BlockGroup     BlockType = "group"
+	BlockParagraph BlockType = "paragraph"
+	BlockHeading   BlockType = "heading"
+	BlockList      BlockType = "list"
+	BlockContents  BlockType = "toc"
+)
+
+// Block is an element of the document body, laid out top to bottom. The set
+// of blocks is closed: Table, TableRef, PageBreak, Spacer, Group, Paragraph,
+// Heading, List and TableOfContents, given by value or by pointer.
+type Block interface {
+	blockType() BlockType
+}
+
+// PageBreak starts a new page. A page break at the top of an empty page does
+// nothing, so it never produces a blank page.
+type PageBreak struct{}
+
+// Spacer adds vertical space. Space that does not fit on the current page
+// is dropped instead of being carried to the next.
+type Spacer struct {
+	Height Length `json:"height"`
+}
+
+// Group keeps its blocks on the same page. A group taller than a page
+// starts on a new page and flows onto the following ones. Groups are
+// encoded as part of a Document, with their tables in its "table" array.
+type Group struct {
+	Blocks []Block
+}
+
+// TableRef places the table Tables[Index] of the document in the body. The
+// builder adds every table to Document.Tables and places it this way, as
+// table entries of the "elements" JSON array do.
+type TableRef struct {
+	Index int
+}
+
+// Paragraph is flowing text wrapped to the content width. Props is a four-
+// or eight-part props string whose font, size, weight and alignment apply;
+// borders are ignored. Line breaks in Text start new paragraphs. Link makes
+// the paragraph clickable, like Cell.Link.
+type Paragraph struct {
+	Props     string `json:"props,omitempty"`
+	Text      string `json:"text"`
+	TextColor Color  `json:"textcolor,omitempty"`
+	Link      string `json:"link,omitempty"`
+}
+
+// Heading is a section heading of level 1 (largest) to 4. Props overrides
+// the default style of the level. A heading is kept on the same page as the
+// start of the block after it.
+type Heading struct {
+	Level     int    `json:"level"`
+	Text      string `json:"text"`
+	Props     string `json:"props,omitempty"`
+	TextColor Color  `json:"textcolor,omitempty"`
+}
+
+// List is a bulleted or, when Ordered, numbered list. Props sets the font of
+// the items; nested lists without props use that of their parent.
+type List struct {
+	Ordered bool       `json:"ordered,omitempty"`
+	Props   string     `json:"props,omitempty"`
+	Items   []ListItem `json:"items"`
+}
+
+// ListItem is an entry of a list, optionally followed by a nested list.
+type ListItem struct {
+	Text string `json:"text"`
+	List *List  `json:"list,omitempty"`
+}
+
+// TableOfContents lists the headings of the document and the tables that a
+// bookmark points to, such as form sections, with their page numbers joined
+// by dotted leaders. Headings deeper than Depth are left out; zero includes
+// every level. Bookmarked tables take the level of their bookmark.
+//
+// Page numbers are resolved by the renderer once the whole document is laid
+// out. Title is drawn above the entries with TitleProps, or as a level 1
+// heading when TitleProps is empty. Props sets the style of the entries.
+type TableOfContents struct {
+	Title      string `json:"title,omitempty"`
+	TitleProps string `json:"titleprops,omitempty"`
+	Props      string `json:"props,omitempty"`
+	Depth      int    `json:"depth,omitempty"`
+}
+
+// Heading levels.
+const (
+	MinHeadingLevel = 1
+	MaxHeadingLevel = 4
+)
+
+func (Table) blockType() BlockType           { return BlockTable }
+func (TableRef) blockType() BlockType        { return BlockTable }
+func (PageBreak) blockType() BlockType       { return BlockPageBreak }
+func (Spacer) blockType() BlockType          { return BlockSpacer }
+func (Group) blockType() BlockType           { return BlockGroup }
+func (Paragraph) blockType() BlockType       { return BlockParagraph }
+func (Heading) blockType() BlockType         { return BlockHeading }
+func (List) blockType() BlockType            { return BlockList }
+func (TableOfContents) blockType() BlockType { return BlockContents }
+
+// marshalElements encodes blocks as the "elements" JSON array: a table as a
+// reference to its index in tables, a group with its own "elements", and
+// other blocks as objects with a "type" member next to their fields. Tables
+// given by value are appended to tables and referenced there.
+func marshalElements(blocks []Block, tables *[]Table) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, 0, len(blocks))
+	for _, b := range blocks {
+		var data []byte
+		var err error
+		switch v := normalizeBlock(b).(type) {
+		case TableRef:
+			data, err = json.Marshal(struct {
+				Type  BlockType `json:"type"`
+				Index int       `json:"index"`
+			}{BlockTable, v.Index})
+		case Table:
+			*tables = append(*tables, v)
+			data, err = json.Marshal(struct {
+				Type  BlockType `json:"type"`
+				Index int       `json:"index"`
+			}{BlockTable, len(*tables) - 1})
+		case PageBreak:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+			}{BlockPageBreak})
+		case Spacer:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+				Spacer
+			}{BlockSpacer, v})
+		case Paragraph:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+				Paragraph
+			}{BlockParagraph, v})
+		case Heading:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+				Heading
+			}{BlockHeading, v})
+		case List:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+				List
+			}{BlockList, v})
+		case TableOfContents:
+			data, err = json.Marshal(struct {
+				Type BlockType `json:"type"`
+				TableOfContents
+			}{BlockContents, v})
+		case Group:
+			var elements []json.RawMessage
+			if elements, err = marshalElements(v.Blocks, tables); err == nil {
+				data, err = json.Marshal(struct {
+					Type     BlockType         `json:"type"`
+					Elements []json.RawMessage `json:"elements"`
+				}{BlockGroup, elements})
+			}
+		default:
+			err = fmt.Errorf("unsupported block %T", b)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// unmarshalElements decodes the "elements" JSON array of a document with
+// tables tables. Table elements become TableRef blocks.
+func unmarshalElements(raw []json.RawMessage, tables int) ([]Block, error) {
+	blocks := make([]Block, 0, len(raw))
+	for i, data := range raw {
+		var tag struct {
+			Type     BlockType         `json:"type"`
+			Index    *int              `json:"index"`
+			Elements []json.RawMessage `json:"elements"`
+		}
+		if err := json.Unmarshal(data, &tag); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		var b Block
+		var err error
+		switch tag.Type {
+		case BlockTable:
+			switch {
+			case tag.Index == nil:
+				err = fmt.Errorf("table element without index")
+			case *tag.Index < 0 || *tag.Index >= tables:
+				err = fmt.Errorf("table index %d out of range [0, %d)", *tag.Index, tables)
+			default:
+				b = TableRef{Index: *tag.Index}
+			}
+		case BlockPageBreak:
+			b = PageBreak{}
+		case BlockSpacer:
+			var s Spacer
+			err = json.Unmarshal(data, &s)
+			b = s
+		case BlockGroup:
+			var g Group
+			g.Blocks, err = unmarshalElements(tag.Elements, tables)
+			b = g
+		case BlockParagraph:
+			var p Paragraph
+			err = json.Unmarshal(data, &p)
+			b = p
+		case BlockHeading:
+			var h Heading
+			err = json.Unmarshal(data, &h)
+			b = h
+		case BlockList:
+			var l List
+			err = json.Unmarshal(data, &l)
+			b = l
+		case BlockContents:
+			var t TableOfContents
+			err = json.Unmarshal(data, &t)
+			b = t
+		default:
+			err = fmt.Errorf("unknown element type %q", tag.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// walkTables calls fn for every table in blocks, including those in groups,
+// in document order.
+func walkTables(blocks []Block, fn func(*Table)) {
+	for i := range blocks {
+		switch v := normalizeBlock(blocks[i]).(type) {
+		case Table:
+			fn(&v)
+		case Group:
+			walkTables(v.Blocks, fn)
+		}
+	}
+}
+
+// normalizeBlock returns the value of a block given by pointer, so that
+// every consumer of the body handles &table like table. A nil pointer
+// yields nil. Other blocks are returned unchanged.
+func normalizeBlock(b Block) Block {
+	switch v := b.(type) {
+	case *Table:
+		if v != nil {
+			return *v
+		}
+	case *TableRef:
+		if v != nil {
+			return *v
+		}
+	case *PageBreak:
+		if v != nil {
+			return *v
+		}
+	case *Spacer:
+		if v != nil {
+			return *v
+		}
+	case *Group:
+		if v != nil {
+			return *v
+		}
+	case *Paragraph:
+		if v != nil {
+			return *v
+		}
+	case *Heading:
+		if v != nil {
+			return *v
+		}
+	case *List:
+		if v != nil {
+			return *v
+		}
+	case *TableOfContents:
+		if v != nil {
+			return *v
+		}
+	default:
+		return b
+	}
+	return nil
+}
+
+// resolveBlocks returns a copy of blocks with every block, including those
+// in groups, normalized by normalizeBlock and every TableRef replaced by the
+// table it refers to. References out of range are kept for Validate to
+// report.
+func resolveBlocks(blocks []Block, tables []Table) []Block {
+	out := make([]Block, len(blocks))
+	for i, b := range blocks {
+		switch v := normalizeBlock(b).(type) {
+		case TableRef:
+			if v.Index >= 0 && v.Index < len(tables) {
+				b = tables[v.Index]
+			} else {
+				b = v
+			}
+		case Group:
+			b = Group{Blocks: resolveBlocks(v.Blocks, tables)}
+		default:
+			b = v
+		}
+		out[i] = b
+	}
+	return out
+}
+
+// walkTableRefs calls fn for every TableRef in blocks, including those in
+// groups, in document order.
+func walkTableRefs(blocks []Block, fn func(TableRef)) {
+	for _, b := range blocks {
+		switch v := normalizeBlock(b).(type) {
+		case TableRef:
+			fn(v)
+		case Group:
+			walkTableRefs(v.Blocks, fn)
+		}
+	}
+}
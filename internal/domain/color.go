@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Color is a color in the "#RRGGBB" form understood by the server.
+// Use ParseColor to convert hex, RGB or named colors.
+type Color string
+
+// palette holds the named colors accepted by ParseColor.
+var palette = map[string]Color{
+	"black":     "#000000",
+	"white":     "#FFFFFF",
+	"red":       "#FF0000",
+	"green":     "#008000",
+	"blue":      "#0000FF",
+	"yellow":    "#FFFF00",
+	"orange":    "#FFA500",
+	"purple":    "#800080",
+	"gray":      "#808080",
+	"grey":      "#808080",
+	"lightgray": "#D3D3D3",
+	"lightgrey": "#D3D3D3",
+	"darkgray":  "#A9A9A9",
+	"darkgrey":  "#A9A9A9",
+	"silver":    "#C0C0C0",
+	"navy":      "#000080",
+	"maroon":    "#800000",
+	"olive":     "#808000",
+	"teal":      "#008080",
+	"lime":      "#00FF00",
+	"aqua":      "#00FFFF",
+	"cyan":      "#00FFFF",
+	"fuchsia":   "#FF00FF",
+	"magenta":   "#FF00FF",
+}
+
+// ParseColor converts "#RGB", "#RRGGBB", "rgb(r, g, b)", "r,g,b" or a named
+// color such as "lightgray" to the canonical "#RRGGBB" form.
+func ParseColor(s string) (Color, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if c, ok := palette[in]; ok {
+		return c, nil
+	}
+
+	if strings.HasPrefix(in, "#") {
+		hex := in[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) == 6 {
+			if _, err := strconv.ParseUint(hex, 16, 32); err == nil {
+				return Color("#" + strings.ToUpper(hex)), nil
+			}
+		}
+		return "", fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+
+	if strings.HasPrefix(in, "rgb(") && strings.HasSuffix(in, ")") {
+		in = in[4 : len(in)-1]
+	}
+	parts := strings.Split(in, ",")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+	var rgb [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || v < 0 || v > 255 {
+			return "", fmt.Errorf("%w: %q: component %q out of range 0-255", ErrInvalidColor, s, strings.TrimSpace(part))
+		}
+		rgb[i] = v
+	}
+	return RGB(uint8(rgb[0]), uint8(rgb[1]), uint8(rgb[2])), nil
+}
+
+// RGB returns the color with the given components.
+func RGB(r, g, b uint8) Color {
+	return Color(fmt.Sprintf("#%02X%02X%02X", r, g, b))
+}
+
+// RGB returns the red, green and blue components of the color.
+func (c Color) RGB() (r, g, b uint8, err error) {
+	parsed, err := ParseColor(string(c))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	v, _ := strconv.ParseUint(string(parsed[1:]), 16, 32)
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
+
+// UnmarshalJSON normalizes recognized colors to "#RRGGBB". Unrecognized
+// values are kept as-is so that Document.Validate can report them.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if parsed, err := ParseColor(s); err == nil {
+		*c = parsed
+	} else {
+		*c = Color(s)
+	}
+	return nil
+}
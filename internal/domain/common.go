@@ -1,18 +1,22 @@
 package domain
 
-// Image represents an image in the document.
-type Image struct {
-	Path   string  `json:"path,omitempty"`
-	X      float64 `json:"x,omitempty"`
-	Y      float64 `json:"y,omitempty"`
-	Width  float64 `json:"width,omitempty"`
-	Height float64 `json:"height,omitempty"`
-}
-
-// Footer represents the document footer.
+// Footer represents the document footer. Text is placed according to the
+// Font alignment; the slots add left, center and right texts. Both may
+// contain the Token constants.
 type Footer struct {
 	Font string `json:"font"`
 	Text string `json:"text"`
+	PageSlots
+}
+
+// Style parses the footer font into a typed Style. Footer fonts carry no borders.
+func (f Footer) Style() (Style, error) {
+	return ParseProps(f.Font)
+}
+
+// SetStyle sets the footer font from a typed Style; borders are ignored.
+func (f *Footer) SetStyle(s Style) {
+	f.Font = s.FontString()
 }
 
 // PageSize represents common page sizes.
@@ -22,8 +26,24 @@ const (
 	PageSizeA4     PageSize = "A4"
 	PageSizeLetter PageSize = "Letter"
 	PageSizeLegal  PageSize = "Legal"
+
+	// PageSizeCustom uses Config.PageWidth and Config.PageHeight.
+	PageSizeCustom PageSize = "Custom"
 )
 
+// pageDimensions holds the portrait width and height of the standard sizes.
+var pageDimensions = map[PageSize][2]Length{
+	PageSizeA4:     {Mm(210), Mm(297)},
+	PageSizeLetter: {In(8.5), In(11)},
+	PageSizeLegal:  {In(8.5), In(14)},
+}
+
+// Dimensions returns the portrait width and height of a standard page size.
+func (p PageSize) Dimensions() (width, height Length, ok bool) {
+	d, ok := pageDimensions[p]
+	return d[0], d[1], ok
+}
+
 // Alignment represents text alignment options.
 type Alignment string
 
@@ -31,4 +51,7 @@ const (
 	AlignLeft   Alignment = "left"
 	AlignCenter Alignment = "center"
 	AlignRight  Alignment = "right"
+	// AlignJustify stretches every line but the last of each paragraph to
+	// the full width.
+	AlignJustify Alignment = "justify"
 )
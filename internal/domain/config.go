@@ -1,9 +1,94 @@
 package domain
 
+import "fmt"
+
 // Config holds the page configuration settings.
+//
+// Page selects a standard size, or PageSizeCustom together with PageWidth and
+// PageHeight. Orientation takes precedence over the older PageAlignment
+// (1 portrait, 2 landscape). Margins set the distance between the page edges
+// and the content, independently of the PageBorder lines. Security encrypts
+// the document and restricts what readers may do with it. Signature is
+// applied by a client configured with a signer once the PDF is rendered.
 type Config struct {
-	PageBorder    string `json:"pageBorder"`
-	Page          string `json:"page"`
-	PageAlignment int    `json:"pageAlignment"`
-	Watermark     string `json:"watermark"`
+	PageBorder    string      `json:"pageBorder"`
+	Page          string      `json:"page"`
+	PageAlignment int         `json:"pageAlignment"`
+	Watermark     string      `json:"watermark"`
+	PageWidth     Length      `json:"pageWidth,omitempty"`
+	PageHeight    Length      `json:"pageHeight,omitempty"`
+	Orientation   Orientation `json:"orientation,omitempty"`
+	Margins       *Margins    `json:"margins,omitempty"`
+	Security      *Security   `json:"security,omitempty"`
+	Signature     *Signature  `json:"signature,omitempty"`
+}
+
+// Margins holds the page margins.
+type Margins struct {
+	Top    Length `json:"top"`
+	Right  Length `json:"right"`
+	Bottom Length `json:"bottom"`
+	Left   Length `json:"left"`
+}
+
+// UniformMargins returns margins of the same length on every side.
+func UniformMargins(l Length) Margins {
+	return Margins{Top: l, Right: l, Bottom: l, Left: l}
+}
+
+// PageAlignment values for Config.PageAlignment.
+const (
+	PageAlignmentPortrait  = 1
+	PageAlignmentLandscape = 2
+)
+
+// PageOrientation returns Orientation when set, landscape when PageAlignment
+// is 2, and "" when neither is specified.
+func (c Config) PageOrientation() Orientation {
+	switch {
+	case c.Orientation != "":
+		return c.Orientation
+	case c.PageAlignment == PageAlignmentLandscape:
+		return OrientationLandscape
+	case c.PageAlignment == PageAlignmentPortrait:
+		return OrientationPortrait
+	}
+	return ""
+}
+
+// PageDimensions returns the page width and height after applying the custom
+// size and orientation. An empty Page means A4. Custom sizes keep their own
+// orientation unless Orientation is set explicitly.
+func (c Config) PageDimensions() (width, height Length, err error) {
+	orientation := c.PageOrientation()
+	switch page := PageSize(c.Page); {
+	case page == PageSizeCustom || (page == "" && (c.PageWidth != 0 || c.PageHeight != 0)):
+		if c.PageWidth <= 0 || c.PageHeight <= 0 {
+			return 0, 0, fmt.Errorf("%w: custom page size needs a positive width and height, got %v x %v",
+				ErrInvalidConfig, c.PageWidth, c.PageHeight)
+		}
+		width, height = c.PageWidth, c.PageHeight
+		orientation = c.Orientation
+	case page == "":
+		width, height, _ = PageSizeA4.Dimensions()
+	default:
+		var ok bool
+		if width, height, ok = page.Dimensions(); !ok {
+			return 0, 0, fmt.Errorf("%w: unknown page size %q", ErrInvalidConfig, c.Page)
+		}
+	}
+
+	if (orientation == OrientationLandscape && width < height) ||
+		(orientation == OrientationPortrait && width > height) {
+		width, height = height, width
+	}
+	return width, height, nil
+}
+
+// PageMargins returns Margins, or defaultMargin on every side when unset.
+func (c Config) PageMargins(defaultMargin Length) Margins {
+	if c.Margins != nil {
+		return *c.Margins
+	}
+	return UniformMargins(defaultMargin)
 }
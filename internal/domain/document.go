@@ -1,12 +1,159 @@
 package domain
 
+import "encoding/json"
+
 // Document represents the complete PDF document structure.
+//
+// The content below the title is the blocks of Body in layout order, which
+// place the tables of Tables with TableRef blocks. Tables that no TableRef
+// refers to come first, so a document made only of tables may list them in
+// Tables alone. Body may also hold tables directly; blocks may be given by
+// value or by pointer.
+//
+// In JSON, every table is written to the "table" array, and the layout
+// order to the "elements" array, in which a table is an entry of type
+// "table" with its index in "table", and page breaks, spacers, groups and
+// text blocks are objects with a "type" member next to their fields.
+// Tables held directly by Body are appended to "table" and referred to in
+// the same way.
+//
+// Bookmarks form the outline shown in the navigation pane of PDF viewers.
+// Shapes and TextBoxes are placed at fixed positions on their pages,
+// independently of the body.
 type Document struct {
-	Config Config  `json:"config"`
-	Title  Title   `json:"title"`
-	Tables []Table `json:"table"`
-	Images []Image `json:"image"`
-	Footer Footer  `json:"footer"`
+	Metadata  *Metadata
+	Config    Config
+	Title     Title
+	Tables    []Table
+	Body      []Block
+	Images    []Image
+	Shapes    []Shape
+	TextBoxes []TextBox
+	Header    *Header
+	Footer    Footer
+	Bookmarks []Bookmark
+}
+
+// documentJSON is the JSON form of a Document.
+type documentJSON struct {
+	Metadata  *Metadata         `json:"metadata,omitempty"`
+	Config    Config            `json:"config"`
+	Title     Title             `json:"title"`
+	Tables    []Table           `json:"table"`
+	Elements  []json.RawMessage `json:"elements,omitempty"`
+	Images    []Image           `json:"image"`
+	Shapes    []Shape           `json:"shapes,omitempty"`
+	TextBoxes []TextBox         `json:"textboxes,omitempty"`
+	Header    *Header           `json:"header,omitempty"`
+	Footer    Footer            `json:"footer"`
+	Bookmarks []Bookmark        `json:"bookmarks,omitempty"`
+}
+
+// MarshalJSON encodes the document, writing the body as described on
+// Document.
+func (d Document) MarshalJSON() ([]byte, error) {
+	out := documentJSON{
+		Metadata:  d.Metadata,
+		Config:    d.Config,
+		Title:     d.Title,
+		Images:    d.Images,
+		Shapes:    d.Shapes,
+		TextBoxes: d.TextBoxes,
+		Header:    d.Header,
+		Footer:    d.Footer,
+		Bookmarks: d.Bookmarks,
+	}
+	out.Tables = append(make([]Table, 0, len(d.Tables)), d.Tables...)
+	var body []Block
+	referenced := d.referencedTables()
+	for i := range d.Tables {
+		if !referenced[i] {
+			body = append(body, TableRef{Index: i})
+		}
+	}
+	elements, err := marshalElements(append(body, d.Body...), &out.Tables)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) > 0 {
+		out.Elements = elements
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a document. When "elements" lists every table once
+// in order and nothing else, Body is left empty.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var in documentJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*d = Document{
+		Metadata:  in.Metadata,
+		Config:    in.Config,
+		Title:     in.Title,
+		Images:    in.Images,
+		Shapes:    in.Shapes,
+		TextBoxes: in.TextBoxes,
+		Header:    in.Header,
+		Footer:    in.Footer,
+		Bookmarks: in.Bookmarks,
+	}
+	d.Tables = in.Tables
+	if len(in.Elements) > 0 {
+		blocks, err := unmarshalElements(in.Elements, len(in.Tables))
+		if err != nil {
+			return err
+		}
+		if !tablesInOrder(blocks, len(in.Tables)) {
+			d.Body = blocks
+		}
+	}
+	return nil
+}
+
+// tablesInOrder reports whether blocks are references to the tables 0 to
+// n-1 in order.
+func tablesInOrder(blocks []Block, n int) bool {
+	if len(blocks) != n {
+		return false
+	}
+	for i, b := range blocks {
+		if b != (TableRef{Index: i}) {
+			return false
+		}
+	}
+	return true
+}
+
+// referencedTables returns the indexes of Tables that Body refers to.
+func (d *Document) referencedTables() map[int]bool {
+	referenced := make(map[int]bool)
+	walkTableRefs(d.Body, func(r TableRef) { referenced[r.Index] = true })
+	return referenced
+}
+
+// Blocks returns the content below the title in layout order: the tables
+// that Body does not refer to, followed by Body with every TableRef replaced
+// by its table and blocks given by pointer, including those in groups,
+// replaced by their values.
+func (d *Document) Blocks() []Block {
+	var blocks []Block
+	referenced := d.referencedTables()
+	for i, t := range d.Tables {
+		if !referenced[i] {
+			blocks = append(blocks, t)
+		}
+	}
+	return append(blocks, resolveBlocks(d.Body, d.Tables)...)
+}
+
+// AllTables returns every table of Tables and Body, including those in
+// groups, in document order. The title table is not included.
+func (d *Document) AllTables() []Table {
+	var tables []Table
+	walkTables(d.Blocks(), func(t *Table) { tables = append(tables, *t) })
+	return tables
 }
 
 // Title represents the document title.
@@ -15,3 +162,33 @@ type Title struct {
 	Text  string `json:"text"`
 	Table *Table `json:"table,omitempty"`
 }
+
+// Style parses the title props into a typed Style.
+func (t Title) Style() (Style, error) {
+	return ParseProps(t.Props)
+}
+
+// SetStyle sets the title props from a typed Style.
+func (t *Title) SetStyle(s Style) {
+	t.Props = s.String()
+}
+
+// FormFields returns the form fields of the title table and all body tables
+// in document order.
+func (d *Document) FormFields() []FormField {
+	var fields []FormField
+	collect := func(t *Table) {
+		for _, row := range t.Rows {
+			for _, cell := range row.Cells {
+				if cell.FormField != nil {
+					fields = append(fields, *cell.FormField)
+				}
+			}
+		}
+	}
+	if d.Title.Table != nil {
+		collect(d.Title.Table)
+	}
+	walkTables(d.Blocks(), collect)
+	return fields
+}
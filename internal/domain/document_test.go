@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// decodeKeys decodes the named members of a JSON object into generic values.
+func decodeKeys(t *testing.T, data []byte, keys ...string) map[string]any {
+	t.Helper()
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]any, len(keys))
+	for _, k := range keys {
+		out[k] = all[k]
+	}
+	return out
+}
+
+// dropFalse removes the members named key whose value is false from the
+// objects in v, which a field tagged omitempty leaves out.
+func dropFalse(v any, key string) {
+	switch v := v.(type) {
+	case map[string]any:
+		if v[key] == false {
+			delete(v, key)
+		}
+		for _, m := range v {
+			dropFalse(m, key)
+		}
+	case []any:
+		for _, e := range v {
+			dropFalse(e, key)
+		}
+	}
+}
+
+func TestDocumentSampleRoundTrip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "sample.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(doc.Tables) != 28 || len(doc.Body) != 0 {
+		t.Fatalf("read %d tables and %d body blocks, want 28 tables and an empty body", len(doc.Tables), len(doc.Body))
+	}
+	if err := doc.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	keys := []string{"config", "title", "table", "elements", "footer"}
+	want, got := decodeKeys(t, data, keys...), decodeKeys(t, out, keys...)
+	dropFalse(want["table"], "checked")
+	for _, k := range keys {
+		if !reflect.DeepEqual(got[k], want[k]) {
+			t.Errorf("%q differs after a round trip\n got %v\nwant %v", k, got[k], want[k])
+		}
+	}
+}
+
+func TestDocumentElements(t *testing.T) {
+	a := Table{MaxColumns: 1, ColumnWidths: []float64{1}, Rows: []Row{{Cells: []Cell{{Props: "font1:10:000:left", Text: "A"}}}}}
+	b := Table{MaxColumns: 1, ColumnWidths: []float64{1}, Rows: []Row{{Cells: []Cell{{Props: "font1:10:000:left", Text: "B"}}}}}
+	c := Table{MaxColumns: 1, ColumnWidths: []float64{1}, Rows: []Row{{Cells: []Cell{{Props: "font1:10:000:left", Text: "C"}}}}}
+
+	tests := []struct {
+		name         string
+		doc          Document
+		wantTables   int
+		wantElements string  // empty when there is no "elements" member
+		wantBlocks   []Block // the blocks after a round trip
+	}{
+		{
+			name:         "tables only",
+			doc:          Document{Tables: []Table{a, b}},
+			wantTables:   2,
+			wantElements: `[{"type":"table","index":0},{"type":"table","index":1}]`,
+			wantBlocks:   []Block{a, b},
+		},
+		{
+			name: "empty",
+			doc:  Document{},
+		},
+		{
+			name: "references",
+			doc: Document{
+				Tables: []Table{a, b, c},
+				Body:   []Block{TableRef{0}, PageBreak{}, TableRef{1}, Spacer{Height: 12}, Group{Blocks: []Block{&TableRef{2}}}},
+			},
+			wantTables:   3,
+			wantElements: `[{"type":"table","index":0},{"type":"pagebreak"},{"type":"table","index":1},{"type":"spacer","height":12},{"type":"group","elements":[{"type":"table","index":2}]}]`,
+			wantBlocks:   []Block{a, PageBreak{}, b, Spacer{Height: 12}, Group{Blocks: []Block{c}}},
+		},
+		{
+			name:         "unreferenced tables first",
+			doc:          Document{Tables: []Table{a, b}, Body: []Block{Heading{Level: 1, Text: "H"}, TableRef{1}}},
+			wantTables:   2,
+			wantElements: `[{"type":"table","index":0},{"type":"heading","level":1,"text":"H"},{"type":"table","index":1}]`,
+			wantBlocks:   []Block{a, Heading{Level: 1, Text: "H"}, b},
+		},
+		{
+			name:         "tables in the body",
+			doc:          Document{Tables: []Table{a}, Body: []Block{PageBreak{}, &b, Group{Blocks: []Block{c}}}},
+			wantTables:   3,
+			wantElements: `[{"type":"table","index":0},{"type":"pagebreak"},{"type":"table","index":1},{"type":"group","elements":[{"type":"table","index":2}]}]`,
+			wantBlocks:   []Block{a, PageBreak{}, b, Group{Blocks: []Block{c}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(tt.doc)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var raw struct {
+				Tables   []json.RawMessage `json:"table"`
+				Elements json.RawMessage   `json:"elements"`
+			}
+			if err := json.Unmarshal(out, &raw); err != nil {
+				t.Fatal(err)
+			}
+			if raw.Tables == nil || len(raw.Tables) != tt.wantTables {
+				t.Errorf("table = %d tables (nil: %v), want %d", len(raw.Tables), raw.Tables == nil, tt.wantTables)
+			}
+			if got := string(raw.Elements); got != tt.wantElements {
+				t.Errorf("elements\n got %s\nwant %s", got, tt.wantElements)
+			}
+
+			var back Document
+			if err := json.Unmarshal(out, &back); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got := back.Blocks(); !reflect.DeepEqual(got, tt.wantBlocks) {
+				t.Errorf("blocks after a round trip\n got %#v\nwant %#v", got, tt.wantBlocks)
+			}
+		})
+	}
+}
+
+func TestDocumentElementErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"index out of range", `{"table":[],"elements":[{"type":"table","index":0}]}`},
+		{"negative index", `{"table":[{}],"elements":[{"type":"table","index":-1}]}`},
+		{"missing index", `{"table":[{}],"elements":[{"type":"table"}]}`},
+		{"out of range in group", `{"table":[{}],"elements":[{"type":"group","elements":[{"type":"table","index":1}]}]}`},
+		{"unknown type", `{"table":[],"elements":[{"type":"chart"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc Document
+			if err := json.Unmarshal([]byte(tt.json), &doc); err == nil {
+				t.Fatal("Unmarshal succeeded")
+			}
+		})
+	}
+}
@@ -10,6 +10,9 @@ var (
 	// ErrInvalidConfig is returned when the document configuration is invalid.
 	ErrInvalidConfig = errors.New("invalid document configuration")
 
+	// ErrInvalidDocument is returned when a document fails validation.
+	ErrInvalidDocument = errors.New("invalid document")
+
 	// ErrEmptyDocument is returned when attempting to send an empty document.
 	ErrEmptyDocument = errors.New("document is empty")
 
@@ -36,6 +39,37 @@ var (
 
 	// ErrServerError is returned when the server returns an error.
 	ErrServerError = errors.New("server error")
+
+	// ErrInvalidProps is returned when a props string cannot be parsed.
+	ErrInvalidProps = errors.New("invalid props")
+
+	// ErrInvalidColor is returned when a color cannot be parsed.
+	ErrInvalidColor = errors.New("invalid color")
+
+	// ErrInvalidImage is returned when image data cannot be decoded or has
+	// an unsupported format.
+	ErrInvalidImage = errors.New("invalid image")
+
+	// ErrInvalidBarcode is returned when data cannot be encoded in the
+	// requested barcode symbology.
+	ErrInvalidBarcode = errors.New("invalid barcode")
+
+	// ErrInvalidPDF is returned when PDF bytes cannot be parsed.
+	ErrInvalidPDF = errors.New("invalid PDF")
+
+	// ErrInvalidKey is returned when a signing key or certificate cannot be
+	// loaded, for example because of a wrong password.
+	ErrInvalidKey = errors.New("invalid signing key or certificate")
+
+	// ErrInvalidSignature is returned when a PDF signature does not verify.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrNoInputs is returned when an operation needs at least one input PDF.
+	ErrNoInputs = errors.New("no input PDFs provided")
+
+	// ErrUnsupported is returned when the client cannot perform the operation,
+	// for example a server-only endpoint on a local client.
+	ErrUnsupported = errors.New("operation not supported by this client")
 )
 
 // HTTPError represents an HTTP error with status code.
@@ -1,20 +1,191 @@
 package domain
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // FormField represents an interactive form field.
+//
+// Dropdowns and list boxes offer Options; Value holds the selected option,
+// and a list box with MultiSelect holds its selections in Selected instead.
+// A dropdown with Editable also accepts text that is not an option. Date
+// fields format and check their Value with DateFormat, a mask such as
+// "yyyy-mm-dd" built from yyyy, yy, mmmm, mmm, mm, m, dd, d, HH and MM.
+//
+// Required, ReadOnly, Tooltip and TabIndex apply to every field type.
+// MaxLength limits the characters of text, multiline and date fields, and
+// Pattern is a regular expression their value must match in full. TabIndex
+// orders fields for keyboard navigation: fields with a positive index come
+// first, in ascending order, followed by the rest in document order.
+// DefaultValue is the value the field returns to when the form is reset.
 type FormField struct {
-	Type      FormFieldType `json:"type"`
-	Name      string        `json:"name"`
-	Value     string        `json:"value"`
-	Checked   bool          `json:"checked,omitempty"`
-	GroupName string        `json:"group_name,omitempty"`
-	Shape     string        `json:"shape,omitempty"`
+	Type         FormFieldType `json:"type"`
+	Name         string        `json:"name"`
+	Value        string        `json:"value"`
+	Checked      bool          `json:"checked,omitempty"`
+	GroupName    string        `json:"group_name,omitempty"`
+	Shape        string        `json:"shape,omitempty"`
+	Options      []string      `json:"options,omitempty"`
+	Selected     []string      `json:"selected,omitempty"`
+	MultiSelect  bool          `json:"multi_select,omitempty"`
+	Editable     bool          `json:"editable,omitempty"`
+	DateFormat   string        `json:"date_format,omitempty"`
+	Required     bool          `json:"required,omitempty"`
+	ReadOnly     bool          `json:"read_only,omitempty"`
+	MaxLength    int           `json:"max_length,omitempty"`
+	Tooltip      string        `json:"tooltip,omitempty"`
+	TabIndex     int           `json:"tab_index,omitempty"`
+	DefaultValue string        `json:"default_value,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
 }
 
 // FormFieldType represents the type of form field.
 type FormFieldType string
 
 const (
-	FormFieldText     FormFieldType = "text"
-	FormFieldCheckbox FormFieldType = "checkbox"
-	FormFieldRadio    FormFieldType = "radio"
+	FormFieldText      FormFieldType = "text"
+	FormFieldCheckbox  FormFieldType = "checkbox"
+	FormFieldRadio     FormFieldType = "radio"
+	FormFieldDropdown  FormFieldType = "dropdown"
+	FormFieldListbox   FormFieldType = "listbox"
+	FormFieldMultiline FormFieldType = "multiline"
+	FormFieldDate      FormFieldType = "date"
+	FormFieldSignature FormFieldType = "signature"
 )
+
+// DefaultDateFormat is the date mask used when FormField.DateFormat is empty.
+const DefaultDateFormat = "yyyy-mm-dd"
+
+// dateTokens maps date mask tokens to Go time layout elements, longest first.
+var dateTokens = []struct{ mask, layout string }{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"mmmm", "January"},
+	{"mmm", "Jan"},
+	{"mm", "01"},
+	{"m", "1"},
+	{"dd", "02"},
+	{"d", "2"},
+	{"HH", "15"},
+	{"MM", "04"},
+}
+
+// DateLayout converts a date mask such as "dd/mm/yyyy" to a Go time layout.
+// Characters other than the mask tokens are copied as separators; letters
+// outside the tokens are rejected.
+func DateLayout(mask string) (string, error) {
+	var b strings.Builder
+	for rest := mask; rest != ""; {
+		matched := false
+		for _, t := range dateTokens {
+			if strings.HasPrefix(rest, t.mask) {
+				b.WriteString(t.layout)
+				rest = rest[len(t.mask):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if c := rest[0]; (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			return "", fmt.Errorf("unsupported character %q in date format %q", c, mask)
+		}
+		b.WriteByte(rest[0])
+		rest = rest[1:]
+	}
+	return b.String(), nil
+}
+
+// DateMask returns the field's DateFormat, or DefaultDateFormat when empty.
+func (f FormField) DateMask() string {
+	if f.DateFormat == "" {
+		return DefaultDateFormat
+	}
+	return f.DateFormat
+}
+
+// IsText reports whether the field holds free text: a text, multiline or
+// date field.
+func (f FormField) IsText() bool {
+	return f.Type == FormFieldText || f.Type == FormFieldMultiline || f.Type == FormFieldDate
+}
+
+// PatternRegexp compiles Pattern anchored at both ends, so that it matches
+// only the whole value. It returns nil when the field has no pattern.
+func (f FormField) PatternRegexp() (*regexp.Regexp, error) {
+	if f.Pattern == "" {
+		return nil, nil
+	}
+	if _, err := regexp.Compile(f.Pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + f.Pattern + ")$")
+}
+
+// IsChoice reports whether the field is a dropdown or list box.
+func (f FormField) IsChoice() bool {
+	return f.Type == FormFieldDropdown || f.Type == FormFieldListbox
+}
+
+// Selection returns the selected options of a dropdown or list box.
+func (f FormField) Selection() []string {
+	if f.Type == FormFieldListbox && f.MultiSelect {
+		return f.Selected
+	}
+	if f.Value == "" {
+		return nil
+	}
+	return []string{f.Value}
+}
+
+// FieldStateOff is the value of an unchecked checkbox or a radio group with no selection.
+const FieldStateOff = "Off"
+
+// OnState returns the export value of a checkbox or radio button when it is on.
+// It is the field Value, or "Yes" when the value is empty.
+func (f FormField) OnState() string {
+	if f.Value == "" {
+		return "Yes"
+	}
+	return f.Value
+}
+
+// FieldValues maps form fields to the values used to fill a PDF form. List
+// boxes map Name to each of their selections, which may be none or, for a
+// multi-select list box, several; other fields have one value. Text fields
+// map Name to Value. Checkboxes map Name to
+// their on state when Checked and to "Off" otherwise. Radio buttons map
+// GroupName (or Name when the group is empty) to the on state of the checked
+// option, or "Off" if none is checked. Signature fields cannot be filled and
+// are left out.
+func FieldValues(fields ...FormField) map[string][]string {
+	values := make(map[string][]string, len(fields))
+	for _, f := range fields {
+		switch f.Type {
+		case FormFieldSignature:
+		case FormFieldListbox:
+			values[f.Name] = f.Selection()
+		case FormFieldCheckbox:
+			values[f.Name] = []string{FieldStateOff}
+			if f.Checked {
+				values[f.Name] = []string{f.OnState()}
+			}
+		case FormFieldRadio:
+			group := f.GroupName
+			if group == "" {
+				group = f.Name
+			}
+			if f.Checked {
+				values[group] = []string{f.OnState()}
+			} else if _, ok := values[group]; !ok {
+				values[group] = []string{FieldStateOff}
+			}
+		default:
+			values[f.Name] = []string{f.Value}
+		}
+	}
+	return values
+}
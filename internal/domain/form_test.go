@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldValues(t *testing.T) {
+	fields := []FormField{
+		{Type: FormFieldText, Name: "first_name", Value: "Michael"},
+		{Type: FormFieldCheckbox, Name: "smoker", Value: "yes"},
+		{Type: FormFieldCheckbox, Name: "consent", Checked: true},
+		{Type: FormFieldRadio, Name: "gender_male", GroupName: "gender", Value: "male"},
+		{Type: FormFieldRadio, Name: "gender_female", GroupName: "gender", Value: "female", Checked: true},
+		{Type: FormFieldRadio, Name: "plan", Value: "basic"},
+		{Type: FormFieldDropdown, Name: "state", Value: "CA", Options: []string{"CA", "NY"}},
+		{Type: FormFieldListbox, Name: "allergies", MultiSelect: true, Options: []string{"Latex", "Penicillin", "Pollen"}, Selected: []string{"Latex", "Pollen"}},
+		{Type: FormFieldListbox, Name: "blood_type", Value: "A+", Options: []string{"A+", "B+"}},
+		{Type: FormFieldListbox, Name: "diet", Options: []string{"Vegan"}},
+		{Type: FormFieldSignature, Name: "approval"},
+	}
+	want := map[string][]string{
+		"first_name": {"Michael"},
+		"smoker":     {"Off"},
+		"consent":    {"Yes"},
+		"gender":     {"female"},
+		"plan":       {"Off"},
+		"state":      {"CA"},
+		"allergies":  {"Latex", "Pollen"},
+		"blood_type": {"A+"},
+		"diet":       nil,
+	}
+	if got := FieldValues(fields...); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldValues =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import "fmt"
+
+// Placement is the position of a cell in the table grid after spans are applied.
+type Placement struct {
+	Column  int
+	ColSpan int
+	RowSpan int
+}
+
+// SpanProblem describes a row or cell that does not fit the table grid.
+// Cell is -1 when the problem concerns the row as a whole.
+type SpanProblem struct {
+	Row     int
+	Cell    int
+	Message string
+}
+
+// Place lays the cells of every row onto a MaxColumns-wide grid. Each cell
+// takes the next columns not covered by a row-spanning cell from a row above.
+// Cells that do not fit are clipped to the grid and reported as problems;
+// a clipped cell beyond the last column gets a ColSpan of 0.
+func (t Table) Place() ([][]Placement, []SpanProblem) {
+	n := t.MaxColumns
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var problems []SpanProblem
+	problem := func(row, cell int, format string, args ...interface{}) {
+		problems = append(problems, SpanProblem{Row: row, Cell: cell, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// covered[c] is the number of rows below the current one that a
+	// row-spanning cell still occupies in column c; origin records that cell.
+	covered := make([]int, n)
+	origin := make([][2]int, n)
+	placements := make([][]Placement, len(t.Rows))
+
+	for r, row := range t.Rows {
+		next := make([]int, n)
+		used := 0
+		for c := range covered {
+			if covered[c] > 0 {
+				used++
+			}
+		}
+
+		col := 0
+		for ci, cell := range row.Cells {
+			if cell.ColSpan < 0 || cell.RowSpan < 0 {
+				problem(r, ci, "spans must not be negative")
+			}
+			cs, rs := max(cell.ColSpan, 1), max(cell.RowSpan, 1)
+
+			for col < n && covered[col] > 0 {
+				col++
+			}
+			if col+cs > n {
+				problem(r, ci, "extends past column %d", n)
+				cs = max(n-col, 0)
+			}
+			for k := col; k < col+cs; k++ {
+				if covered[k] > 0 {
+					problem(r, ci, "overlaps the row-spanning cell at row %d", origin[k][0])
+				}
+				next[k] = rs - 1
+				origin[k] = [2]int{r, ci}
+			}
+			placements[r] = append(placements[r], Placement{Column: col, ColSpan: cs, RowSpan: rs})
+			used += cs
+			col += cs
+		}
+		if used != n {
+			problem(r, -1, "cells span %d columns, expected %d", used, n)
+		}
+
+		for c := range covered {
+			if covered[c] > 0 {
+				covered[c]--
+			} else {
+				covered[c] = next[c]
+			}
+		}
+	}
+
+	reported := make(map[[2]int]bool)
+	for c := range covered {
+		if covered[c] > 0 && !reported[origin[c]] {
+			reported[origin[c]] = true
+			problem(origin[c][0], origin[c][1], "rowspan extends past the last row")
+		}
+	}
+	return placements, problems
+}
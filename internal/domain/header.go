@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tokens replaced in header and footer text when the document is rendered.
+const (
+	TokenPage  = "{page}"  // current page number, starting at 1
+	TokenPages = "{pages}" // total number of pages
+	TokenDate  = "{date}"  // rendering date as YYYY-MM-DD
+	TokenTitle = "{title}" // title text
+)
+
+// PageScope selects the pages a header or footer appears on.
+type PageScope string
+
+const (
+	// ShowAllPages shows the section on every page. It is the default.
+	ShowAllPages PageScope = "all"
+	// ShowFirstPageOnly shows the section on the first page only.
+	ShowFirstPageOnly PageScope = "first"
+	// ShowExceptFirstPage shows the section on every page but the first.
+	ShowExceptFirstPage PageScope = "not-first"
+)
+
+// PageSlots holds the left, center and right texts of a header or footer and
+// the pages it appears on. The texts may contain the Token constants.
+type PageSlots struct {
+	Left   string    `json:"left,omitempty"`
+	Center string    `json:"center,omitempty"`
+	Right  string    `json:"right,omitempty"`
+	Show   PageScope `json:"show,omitempty"`
+}
+
+// HasSlots reports whether any slot has text.
+func (s PageSlots) HasSlots() bool {
+	return s.Left != "" || s.Center != "" || s.Right != ""
+}
+
+// ShownOn reports whether the section appears on the given 1-based page.
+func (s PageSlots) ShownOn(page int) bool {
+	switch s.Show {
+	case ShowFirstPageOnly:
+		return page == 1
+	case ShowExceptFirstPage:
+		return page > 1
+	default:
+		return true
+	}
+}
+
+// Header represents the page header drawn above the content of each page.
+// Font uses the four-part props form, like Footer.Font; its alignment is
+// ignored because every slot has a fixed position.
+type Header struct {
+	Font string `json:"font"`
+	PageSlots
+}
+
+// Style parses the header font into a typed Style.
+func (h Header) Style() (Style, error) {
+	return ParseProps(h.Font)
+}
+
+// SetStyle sets the header font from a typed Style.
+func (h *Header) SetStyle(s Style) {
+	h.Font = s.FontString()
+}
+
+// PageInfo holds the values substituted for the header and footer tokens.
+type PageInfo struct {
+	Page  int
+	Pages int
+	Date  time.Time
+	Title string
+}
+
+// ExpandTokens replaces the {page}, {pages}, {date} and {title} tokens in text.
+func ExpandTokens(text string, info PageInfo) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	return strings.NewReplacer(
+		TokenPage, strconv.Itoa(info.Page),
+		TokenPages, strconv.Itoa(info.Pages),
+		TokenDate, info.Date.Format("2006-01-02"),
+		TokenTitle, info.Title,
+	).Replace(text)
+}
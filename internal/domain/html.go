@@ -0,0 +1,55 @@
+package domain
+
+import "fmt"
+
+// HTMLDocument describes an HTML page to convert to a PDF or an image.
+// Exactly one of HTML and URL must be set.
+type HTMLDocument struct {
+	HTML        string      `json:"html,omitempty"`
+	URL         string      `json:"url,omitempty"`
+	PageSize    PageSize    `json:"page_size,omitempty"`
+	Orientation Orientation `json:"orientation,omitempty"`
+	HTMLMargins
+	Quality int `json:"quality,omitempty"`
+	Width   int `json:"width,omitempty"`
+	Height  int `json:"height,omitempty"`
+}
+
+// HTMLMargins holds page margins for HTML conversion as CSS-style lengths, such as "10mm".
+type HTMLMargins struct {
+	Top    string `json:"margin_top,omitempty"`
+	Right  string `json:"margin_right,omitempty"`
+	Bottom string `json:"margin_bottom,omitempty"`
+	Left   string `json:"margin_left,omitempty"`
+}
+
+// Orientation represents the page orientation.
+type Orientation string
+
+const (
+	OrientationPortrait  Orientation = "portrait"
+	OrientationLandscape Orientation = "landscape"
+)
+
+// ImageFormat represents the output format of an HTML-to-image conversion.
+type ImageFormat string
+
+const (
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatJPEG ImageFormat = "jpg"
+	ImageFormatSVG  ImageFormat = "svg"
+)
+
+// Validate reports whether the HTML document has exactly one source and a
+// quality within 0-100.
+func (h *HTMLDocument) Validate() error {
+	switch {
+	case h.HTML == "" && h.URL == "":
+		return fmt.Errorf("%w: html or url is required", ErrEmptyDocument)
+	case h.HTML != "" && h.URL != "":
+		return fmt.Errorf("%w: html and url are mutually exclusive", ErrInvalidConfig)
+	case h.Quality < 0 || h.Quality > 100:
+		return fmt.Errorf("%w: quality must be between 0 and 100", ErrInvalidConfig)
+	}
+	return nil
+}
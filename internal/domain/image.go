@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"  // register the GIF decoder for DecodeImageConfig
+	_ "image/jpeg" // register the JPEG decoder for DecodeImageConfig
+	_ "image/png"  // register the PNG decoder for DecodeImageConfig
+	"strings"
+)
+
+// Supported image MIME types.
+const (
+	MimeTypePNG  = "image/png"
+	MimeTypeJPEG = "image/jpeg"
+	MimeTypeGIF  = "image/gif"
+)
+
+// mimeTypes maps image package format names to MIME types.
+var mimeTypes = map[string]string{
+	"png":  MimeTypePNG,
+	"jpeg": MimeTypeJPEG,
+	"gif":  MimeTypeGIF,
+}
+
+// Image represents an image in the document or in a table cell.
+//
+// The image is either referenced by Path or carried inline as base64 Data
+// with its MimeType. Inline images work with any server; a path is only
+// meaningful on the machine that renders the document. Width and Height are
+// the drawn size; X and Y position document-level images from the
+// top-left corner of the first page and are ignored inside cells.
+type Image struct {
+	Path     string `json:"path,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	X        Length `json:"x,omitempty"`
+	Y        Length `json:"y,omitempty"`
+	Width    Length `json:"width,omitempty"`
+	Height   Length `json:"height,omitempty"`
+}
+
+// NewImage creates an inline image from encoded PNG, JPEG or GIF bytes. The
+// format is detected from the data and Width and Height are set to the pixel
+// dimensions.
+func NewImage(data []byte) (Image, error) {
+	mimeType, width, height, err := DecodeImageConfig(data)
+	if err != nil {
+		return Image{}, err
+	}
+	return Image{
+		Data:     base64.StdEncoding.EncodeToString(data),
+		MimeType: mimeType,
+		Width:    Length(width),
+		Height:   Length(height),
+	}, nil
+}
+
+// ParseDataURI creates an inline image from a "data:image/png;base64,..." URI.
+func ParseDataURI(uri string) (Image, error) {
+	data, err := decodeDataURI(uri)
+	if err != nil {
+		return Image{}, err
+	}
+	return NewImage(data)
+}
+
+// DecodeImageConfig detects the format of encoded image bytes and returns
+// its MIME type and pixel dimensions.
+func DecodeImageConfig(data []byte) (mimeType string, width, height int, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	mimeType, ok := mimeTypes[format]
+	if !ok {
+		return "", 0, 0, fmt.Errorf("%w: unsupported format %q", ErrInvalidImage, format)
+	}
+	return mimeType, cfg.Width, cfg.Height, nil
+}
+
+// Bytes returns the decoded inline image data. Data may hold plain base64
+// or a data URI.
+func (img Image) Bytes() ([]byte, error) {
+	if img.Data == "" {
+		return nil, fmt.Errorf("%w: no inline data", ErrInvalidImage)
+	}
+	if strings.HasPrefix(img.Data, "data:") {
+		return decodeDataURI(img.Data)
+	}
+	data, err := base64.StdEncoding.DecodeString(img.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base64 data: %v", ErrInvalidImage, err)
+	}
+	return data, nil
+}
+
+// DataURI returns the inline image as a data URI, or "" for a path image.
+func (img Image) DataURI() string {
+	if img.Data == "" || strings.HasPrefix(img.Data, "data:") {
+		return img.Data
+	}
+	return "data:" + img.MimeType + ";base64," + img.Data
+}
+
+// Fit returns a copy of the image scaled to fit within maxWidth by maxHeight
+// while keeping its aspect ratio. Images that already fit are unchanged and a
+// zero maximum leaves that side unbounded.
+func (img Image) Fit(maxWidth, maxHeight Length) Image {
+	if img.Width <= 0 || img.Height <= 0 {
+		return img
+	}
+	scale := Length(1)
+	if maxWidth > 0 && img.Width*scale > maxWidth {
+		scale = maxWidth / img.Width
+	}
+	if maxHeight > 0 && img.Height*scale > maxHeight {
+		scale = maxHeight / img.Height
+	}
+	img.Width *= scale
+	img.Height *= scale
+	return img
+}
+
+// decodeDataURI decodes a base64 data URI with an image MIME type.
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok || !strings.HasPrefix(uri, "data:") {
+		return nil, fmt.Errorf("%w: malformed data URI", ErrInvalidImage)
+	}
+	if !strings.HasSuffix(header, ";base64") {
+		return nil, fmt.Errorf("%w: data URI is not base64 encoded", ErrInvalidImage)
+	}
+	if !strings.HasPrefix(header, "image/") {
+		return nil, fmt.Errorf("%w: data URI has non-image type %q", ErrInvalidImage, strings.TrimSuffix(header, ";base64"))
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base64 data: %v", ErrInvalidImage, err)
+	}
+	return data, nil
+}
@@ -18,8 +18,16 @@ type DocumentSender interface {
 	Send(ctx context.Context, doc *Document) ([]byte, error)
 }
 
+// PDFSigner defines the interface for signing rendered PDFs.
+type PDFSigner interface {
+	// Sign applies a digital signature to the PDF and returns the signed bytes.
+	Sign(pdf []byte, sig Signature) ([]byte, error)
+}
+
 // DocumentBuilder defines the interface for building documents using fluent API.
 type DocumentBuilder interface {
+	// WithMetadata sets the document information such as author and keywords.
+	WithMetadata(metadata Metadata) DocumentBuilder
 	// WithConfig sets the document configuration.
 	WithConfig(config Config) DocumentBuilder
 	// WithTitle sets the document title.
@@ -28,10 +36,42 @@ type DocumentBuilder interface {
 	WithTitleTable(table Table) DocumentBuilder
 	// AddTable adds a table to the document.
 	AddTable(table Table) DocumentBuilder
+	// AddPageBreak starts a new page before the next block.
+	AddPageBreak() DocumentBuilder
+	// AddSpacer adds vertical space before the next block.
+	AddSpacer(height Length) DocumentBuilder
+	// AddGroup adds blocks that are kept on the same page.
+	AddGroup(blocks ...Block) DocumentBuilder
+	// AddParagraph adds a paragraph of wrapped text.
+	AddParagraph(props, text string) DocumentBuilder
+	// AddHeading adds a heading of the given level, 1 to 4.
+	AddHeading(level int, text string) DocumentBuilder
+	// AddList adds a bulleted or numbered list.
+	AddList(list List) DocumentBuilder
+	// AddTableOfContents adds a table of contents of the headings and
+	// bookmarked tables of the document.
+	AddTableOfContents(toc TableOfContents) DocumentBuilder
 	// AddImage adds an image to the document.
 	AddImage(image Image) DocumentBuilder
-	// WithFooter sets the document footer.
+	// AddShape adds a line, rectangle or ellipse at a fixed position.
+	AddShape(shape Shape) DocumentBuilder
+	// AddTextBox adds text at a fixed position.
+	AddTextBox(box TextBox) DocumentBuilder
+	// AddQRCode adds a QR code image of the given size at a fixed position
+	// on the first page.
+	AddQRCode(x, y, size Length, data string, opts QROptions) DocumentBuilder
+	// WithHeader sets the page header.
+	WithHeader(header Header) DocumentBuilder
+	// WithFooter sets the document footer font and text.
 	WithFooter(font, text string) DocumentBuilder
+	// WithPageFooter sets the whole footer, including its slots.
+	WithPageFooter(footer Footer) DocumentBuilder
+	// AddBookmark adds an entry to the document outline.
+	AddBookmark(bookmark Bookmark) DocumentBuilder
+	// Err returns the first error of a method that could not add its
+	// content, such as AddQRCode with data too long for a QR code, or a
+	// table whose form fields reuse the tab index of an earlier field.
+	Err() error
 	// Build constructs and returns the final document.
 	Build() *Document
 	// Reset clears the builder state for reuse.
@@ -44,8 +84,22 @@ type TableBuilder interface {
 	WithColumns(maxColumns int, widths []float64) TableBuilder
 	// AddRow adds a row to the table.
 	AddRow(cells ...Cell) TableBuilder
-	// AddRowWithHeight adds a row with custom height.
+	// AddRowWithHeight adds a row with custom height in points.
 	AddRowWithHeight(height int, cells ...Cell) TableBuilder
+	// AddRowWithLength adds a row with a custom height in any unit, rounded
+	// to whole points.
+	AddRowWithLength(height Length, cells ...Cell) TableBuilder
+	// AddHeaderRow adds a header row repeated on every page of the table.
+	AddHeaderRow(cells ...Cell) TableBuilder
+	// KeepRowsTogether moves rows that do not fit on a page to the next one
+	// instead of splitting them.
+	KeepRowsTogether() TableBuilder
+	// KeepWithPrevious keeps the table with the preceding table.
+	KeepWithPrevious() TableBuilder
+	// WithAnchor names the table as a target of internal links and bookmarks.
+	WithAnchor(name string) TableBuilder
+	// AddSpannedRow adds a row whose cells span the given number of columns.
+	AddSpannedRow(colspans []int, cells ...Cell) TableBuilder
 	// Build constructs and returns the final table.
 	Build() Table
 	// Reset clears the builder state for reuse.
@@ -56,6 +110,8 @@ type TableBuilder interface {
 type CellBuilder interface {
 	// WithProps sets the cell properties.
 	WithProps(props string) CellBuilder
+	// WithStyle sets the cell properties from a typed Style.
+	WithStyle(style Style) CellBuilder
 	// WithText sets the cell text.
 	WithText(text string) CellBuilder
 	// WithTextField adds a text form field.
@@ -64,14 +120,64 @@ type CellBuilder interface {
 	WithCheckbox(name, value string, checked bool) CellBuilder
 	// WithRadio adds a radio button form field.
 	WithRadio(name, value, groupName string, checked bool) CellBuilder
+	// WithDropdown adds a combo box with the given options and selected value.
+	WithDropdown(name string, options []string, value string) CellBuilder
+	// WithListbox adds a list box; several selections make it multi-select.
+	WithListbox(name string, options []string, selected ...string) CellBuilder
+	// WithMultiline adds a multiline text area.
+	WithMultiline(name, value string) CellBuilder
+	// WithDate adds a date field with a format mask such as "yyyy-mm-dd".
+	WithDate(name, value, format string) CellBuilder
+	// WithSignature adds an empty signature field.
+	WithSignature(name string) CellBuilder
+	// WithRequired marks the cell's form field as required. The field
+	// attributes may be set before or after the field.
+	WithRequired() CellBuilder
+	// WithReadOnly makes the cell's form field read-only.
+	WithReadOnly() CellBuilder
+	// WithMaxLength limits the characters of the cell's text field.
+	WithMaxLength(n int) CellBuilder
+	// WithTooltip sets the tooltip of the cell's form field.
+	WithTooltip(text string) CellBuilder
+	// WithTabIndex sets the tab order position of the cell's form field.
+	WithTabIndex(index int) CellBuilder
+	// WithDefaultValue sets the value the cell's form field resets to.
+	WithDefaultValue(value string) CellBuilder
+	// WithPattern sets a regular expression the cell's text field must match.
+	WithPattern(pattern string) CellBuilder
+	// WithRuns sets styled text runs, keeping their plain text as the cell text.
+	WithRuns(runs ...TextRun) CellBuilder
+	// WithMarkup sets text runs parsed from inline markup, styled from the
+	// cell props set so far.
+	WithMarkup(markup string) CellBuilder
+	// WithLink makes the cell a link to a URL or a named anchor.
+	WithLink(target string) CellBuilder
+	// WithImage places an image in the cell.
+	WithImage(image Image) CellBuilder
+	// WithColSpan makes the cell span several columns.
+	WithColSpan(n int) CellBuilder
+	// WithRowSpan makes the cell span several rows.
+	WithRowSpan(n int) CellBuilder
+	// WithBackground sets the cell background color.
+	WithBackground(color string) CellBuilder
+	// WithTextColor sets the text color.
+	WithTextColor(color string) CellBuilder
+	// WithBorderColor sets the border color.
+	WithBorderColor(color string) CellBuilder
+	// Err returns the first error of a color setter whose color could not
+	// be parsed; the cell keeps its previous color. Otherwise it reports
+	// field attributes set without a form field, and a value or default
+	// value longer than the field's maximum length.
+	Err() error
 	// Build constructs and returns the final cell.
 	Build() Cell
 }
 
 // HTTPClient defines the interface for HTTP operations.
 type HTTPClient interface {
-	// Do executes an HTTP request.
-	Do(ctx context.Context, method, url string, body io.Reader) ([]byte, error)
+	// Do executes an HTTP request whose body has the given Content-Type.
+	// Requests without a body pass an empty content type.
+	Do(ctx context.Context, method, url, contentType string, body io.Reader) ([]byte, error)
 	// Post sends a POST request with JSON body.
 	Post(ctx context.Context, url string, body interface{}) ([]byte, error)
 	// Get sends a GET request.
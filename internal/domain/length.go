@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Length is a distance in points (1/72 inch). It is sent to the server as a
+// number of points; use the unit helpers instead of converting by hand:
+//
+//	height := domain.Mm(25)     // 25 millimeters
+//	margin := 0.5 * domain.Inch // half an inch
+type Length float64
+
+// Length units.
+const (
+	Point      Length = 1
+	Inch       Length = 72
+	Centimeter Length = 72 / 2.54
+	Millimeter Length = 72 / 25.4
+)
+
+// Pt returns a length of v points.
+func Pt(v float64) Length { return Length(v) }
+
+// Mm returns a length of v millimeters.
+func Mm(v float64) Length { return Length(v) * Millimeter }
+
+// Cm returns a length of v centimeters.
+func Cm(v float64) Length { return Length(v) * Centimeter }
+
+// In returns a length of v inches.
+func In(v float64) Length { return Length(v) * Inch }
+
+// Points returns the length in points.
+func (l Length) Points() float64 { return float64(l) }
+
+// Millimeters returns the length in millimeters.
+func (l Length) Millimeters() float64 { return float64(l / Millimeter) }
+
+// Centimeters returns the length in centimeters.
+func (l Length) Centimeters() float64 { return float64(l / Centimeter) }
+
+// Inches returns the length in inches.
+func (l Length) Inches() float64 { return float64(l / Inch) }
+
+// String returns the length in points rounded to two decimals, for example "12.5pt".
+func (l Length) String() string {
+	return strconv.FormatFloat(math.Round(float64(l)*100)/100, 'f', -1, 64) + "pt"
+}
+
+// lengthUnits maps the suffixes accepted by ParseLength to their size.
+var lengthUnits = []struct {
+	suffix string
+	unit   Length
+}{
+	{"pt", Point},
+	{"mm", Millimeter},
+	{"cm", Centimeter},
+	{"in", Inch},
+}
+
+// ParseLength parses a number with an optional pt, mm, cm or in suffix, such
+// as "25mm" or "0.5in". A bare number is in points.
+func ParseLength(s string) (Length, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	unit := Point
+	for _, u := range lengthUnits {
+		if strings.HasSuffix(in, u.suffix) {
+			in, unit = strings.TrimSpace(strings.TrimSuffix(in, u.suffix)), u.unit
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(in, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid length %q", ErrInvalidConfig, s)
+	}
+	return Length(v) * unit, nil
+}
+
+// UnmarshalJSON accepts a number of points or a string with a unit, such as "25mm".
+func (l *Length) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		*l = Length(v)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("length must be a number or a string: %w", err)
+	}
+	parsed, err := ParseLength(s)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
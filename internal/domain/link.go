@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+// Bookmark is an entry of the document outline that jumps to a named
+// anchor. Children are nested below it.
+type Bookmark struct {
+	Title    string     `json:"title"`
+	Anchor   string     `json:"anchor"`
+	Children []Bookmark `json:"children,omitempty"`
+}
+
+// AnchorLink returns the link target of a named anchor, such as "#terms".
+// Other link targets are absolute URLs.
+func AnchorLink(anchor string) string {
+	return "#" + anchor
+}
+
+// LinkAnchor returns the anchor a link target points to and whether it is
+// an internal link.
+func LinkAnchor(link string) (string, bool) {
+	if !strings.HasPrefix(link, "#") {
+		return "", false
+	}
+	return link[1:], true
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// Metadata holds the document information written to the PDF information
+// dictionary and XMP metadata, used by document management systems for
+// indexing. Custom holds additional properties written under the pdfx XMP
+// namespace and to the information dictionary.
+type Metadata struct {
+	Title        string            `json:"title,omitempty"`
+	Author       string            `json:"author,omitempty"`
+	Subject      string            `json:"subject,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty"`
+	Creator      string            `json:"creator,omitempty"`
+	CreationDate *time.Time        `json:"creation_date,omitempty"`
+	Language     string            `json:"language,omitempty"`
+	Custom       map[string]string `json:"custom,omitempty"`
+}
+
+// standardInfoKeys are the information dictionary keys that custom
+// properties must not override.
+var standardInfoKeys = map[string]bool{
+	"Title": true, "Author": true, "Subject": true, "Keywords": true, "Creator": true,
+	"Producer": true, "CreationDate": true, "ModDate": true, "Trapped": true,
+}
+
+var (
+	// customKeyPattern matches names that are valid both as XML element
+	// names and as PDF names without escaping.
+	customKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	// languagePattern matches BCP 47 language tags such as "en" or "en-US".
+	languagePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+)
+
+// ValidCustomKey reports whether name can be used as a custom metadata property.
+func ValidCustomKey(name string) bool {
+	return customKeyPattern.MatchString(name) && !standardInfoKeys[name]
+}
@@ -0,0 +1,24 @@
+package domain
+
+// QRLevel is the error correction level of a QR code: the share of the
+// symbol that can be damaged while it still scans.
+type QRLevel string
+
+// QR code error correction levels.
+const (
+	QRLevelL QRLevel = "L" // about 7% of the codewords
+	QRLevelM QRLevel = "M" // about 15%; the default
+	QRLevelQ QRLevel = "Q" // about 25%
+	QRLevelH QRLevel = "H" // about 30%
+)
+
+// QROptions configures a QR code added with DocumentBuilder.AddQRCode.
+// The zero value uses QRLevelM and the 4 module quiet zone the
+// specification requires.
+type QROptions struct {
+	// Level is the error correction level. Empty means QRLevelM.
+	Level QRLevel
+	// QuietZone is the blank margin around the symbol in modules. Zero
+	// means the minimum of 4.
+	QuietZone int
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TextRun is a piece of cell text with its own style. A nil Style inherits
+// the cell style; otherwise its font, size, weight and text color apply,
+// while alignment and borders always come from the cell. Link makes the run
+// clickable, like Cell.Link.
+type TextRun struct {
+	Text  string
+	Style *Style
+	Link  string
+}
+
+// textRunJSON is the wire form of a TextRun, which carries the style as a
+// four-part props string and a text color like a Cell does.
+type textRunJSON struct {
+	Text      string `json:"text"`
+	Props     string `json:"props,omitempty"`
+	TextColor Color  `json:"textcolor,omitempty"`
+	Link      string `json:"link,omitempty"`
+}
+
+// MarshalJSON encodes the run with its style as a props string.
+func (r TextRun) MarshalJSON() ([]byte, error) {
+	out := textRunJSON{Text: r.Text, Link: r.Link}
+	if r.Style != nil {
+		out.Props = r.Style.FontString()
+		out.TextColor = r.Style.TextColor
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a run and parses its props string.
+func (r *TextRun) UnmarshalJSON(data []byte) error {
+	var in textRunJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*r = TextRun{Text: in.Text, Link: in.Link}
+	if in.Props != "" {
+		s, err := ParseProps(in.Props)
+		if err != nil {
+			return err
+		}
+		s.TextColor = in.TextColor
+		r.Style = &s
+	} else if in.TextColor != "" {
+		return fmt.Errorf("%w: run text color needs props", ErrInvalidProps)
+	}
+	return nil
+}
+
+// PlainText returns the text of runs without their styles, the form sent in
+// Cell.Text for services that do not read runs.
+func PlainText(runs []TextRun) string {
+	var b strings.Builder
+	for _, r := range runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package domain
+
+// redacted replaces secret values when they are printed.
+const redacted = "[REDACTED]"
+
+// Secret is a sensitive string such as a password. Printing it with fmt
+// shows "[REDACTED]" instead of the value; Reveal returns the value itself.
+// Secrets are encoded in JSON as plain strings, since the service needs them.
+type Secret string
+
+// String returns "[REDACTED]" for a non-empty secret.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString returns the redacted form for the %#v verb.
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+// Reveal returns the secret value.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// Encryption selects the algorithm used to encrypt a secured document.
+type Encryption string
+
+const (
+	// EncryptionAES128 uses 128-bit AES (PDF 1.6, security handler revision 4).
+	EncryptionAES128 Encryption = "aes-128"
+	// EncryptionAES256 uses 256-bit AES (PDF 2.0, security handler revision 6).
+	// It is the default.
+	EncryptionAES256 Encryption = "aes-256"
+)
+
+// Permissions lists what a reader may do when the document is opened
+// without the owner password. Anything not allowed is denied.
+type Permissions struct {
+	Print     bool `json:"print,omitempty"`
+	Copy      bool `json:"copy,omitempty"`
+	Modify    bool `json:"modify,omitempty"`
+	FillForms bool `json:"fillForms,omitempty"`
+	Annotate  bool `json:"annotate,omitempty"`
+}
+
+// AllPermissions returns a permission set allowing everything.
+func AllPermissions() Permissions {
+	return Permissions{Print: true, Copy: true, Modify: true, FillForms: true, Annotate: true}
+}
+
+// Security holds the passwords, permissions and encryption algorithm of a
+// secured document. The user password is needed to open the document; the
+// owner password lifts the permission restrictions. Without a user password
+// anyone can open the document but the permissions still apply, and without
+// an owner password a random one is used.
+type Security struct {
+	UserPassword  Secret      `json:"userPassword,omitempty"`
+	OwnerPassword Secret      `json:"ownerPassword,omitempty"`
+	Permissions   Permissions `json:"permissions"`
+	Encryption    Encryption  `json:"encryption,omitempty"`
+}
+
+// Algorithm returns the encryption algorithm, EncryptionAES256 when unset.
+func (s Security) Algorithm() Encryption {
+	if s.Encryption == "" {
+		return EncryptionAES256
+	}
+	return s.Encryption
+}
@@ -0,0 +1,97 @@
+package domain
+
+// ShapeType is the kind of a Shape.
+type ShapeType string
+
+// Shape types.
+const (
+	ShapeLine      ShapeType = "line"
+	ShapeRect      ShapeType = "rect"
+	ShapeRoundRect ShapeType = "roundrect"
+	ShapeEllipse   ShapeType = "ellipse"
+)
+
+// AllPages is the Page of shapes and text boxes drawn on every page.
+const AllPages = 0
+
+// Shape is a line, rectangle, rounded rectangle or ellipse drawn at a fixed
+// position beneath the content of a page.
+//
+// X and Y place the top-left corner of the shape's bounding box from the
+// top-left corner of the page. A line runs from (X, Y) to (X+Width,
+// Y+Height), so a Height of zero draws a horizontal rule. Radius rounds the
+// corners of a rounded rectangle.
+//
+// The outline is drawn in Stroke, StrokeWidth thick, and the inside is
+// filled with Fill; lines are never filled. A shape without either color is
+// outlined in black. StrokeWidth defaults to 1pt. Page is the 1-based page
+// the shape is drawn on, or AllPages.
+type Shape struct {
+	Type        ShapeType `json:"type"`
+	X           Length    `json:"x"`
+	Y           Length    `json:"y"`
+	Width       Length    `json:"width,omitempty"`
+	Height      Length    `json:"height,omitempty"`
+	Radius      Length    `json:"radius,omitempty"`
+	Stroke      Color     `json:"stroke,omitempty"`
+	StrokeWidth Length    `json:"strokewidth,omitempty"`
+	Fill        Color     `json:"fill,omitempty"`
+	Page        int       `json:"page,omitempty"`
+}
+
+// WithStroke returns a copy of the shape outlined in color, width thick. A
+// zero width keeps the default.
+func (s Shape) WithStroke(color Color, width Length) Shape {
+	s.Stroke = color
+	s.StrokeWidth = width
+	return s
+}
+
+// WithFill returns a copy of the shape filled with color.
+func (s Shape) WithFill(color Color) Shape {
+	s.Fill = color
+	return s
+}
+
+// OnPage returns a copy of the shape drawn on the given 1-based page only.
+func (s Shape) OnPage(page int) Shape {
+	s.Page = page
+	return s
+}
+
+// ShownOn reports whether the shape is drawn on the given 1-based page.
+func (s Shape) ShownOn(page int) bool {
+	return s.Page == AllPages || s.Page == page
+}
+
+// TextBox is text drawn at a fixed position above the content of a page.
+//
+// It is drawn like a table cell at X and Y from the top-left corner of the
+// page: Props set the font, alignment and borders, and the text wraps
+// within Width and is centered vertically within Height. A zero Width fits
+// the longest line and a zero Height fits the text. The text may contain
+// the Token constants of headers and footers. Page is the 1-based page the
+// box is drawn on, or AllPages.
+type TextBox struct {
+	X           Length `json:"x"`
+	Y           Length `json:"y"`
+	Width       Length `json:"width,omitempty"`
+	Height      Length `json:"height,omitempty"`
+	Props       string `json:"props"`
+	Text        string `json:"text"`
+	BgColor     Color  `json:"bgcolor,omitempty"`
+	TextColor   Color  `json:"textcolor,omitempty"`
+	BorderColor Color  `json:"bordercolor,omitempty"`
+	Page        int    `json:"page,omitempty"`
+}
+
+// OnPage returns a copy of the text box drawn on the given 1-based page only.
+func (b TextBox) OnPage(page int) TextBox {
+	b.Page = page
+	return b
+}
+
+// ShownOn reports whether the text box is drawn on the given 1-based page.
+func (b TextBox) ShownOn(page int) bool {
+	return b.Page == AllPages || b.Page == page
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"crypto/x509"
+	"time"
+)
+
+// Signature describes the digital signature applied to a document after it
+// is rendered. Field names a signature form field that shows the signature;
+// when it is empty the signature is invisible. Name defaults to the common
+// name of the signing certificate.
+type Signature struct {
+	Field       string `json:"field,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	Location    string `json:"location,omitempty"`
+	ContactInfo string `json:"contact_info,omitempty"`
+}
+
+// SignatureInfo describes a signature found in a PDF that verified. The
+// certificate is reported as embedded in the signature; whether it is
+// trusted is up to the caller.
+type SignatureInfo struct {
+	Field       string
+	Name        string
+	Reason      string
+	Location    string
+	ContactInfo string
+	SigningTime time.Time
+	Certificate *x509.Certificate
+	// CoversWholeFile reports whether the signed byte ranges extend to the
+	// end of the file, that is, nothing was appended after signing.
+	CoversWholeFile bool
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Style is the typed form of a props string such as "font1:9:100:left:1:1:1:1",
+// laid out as font:size:weight:alignment:top:right:bottom:left. The weight is
+// three flags for bold, italic and underline. Footer fonts use only the first
+// four parts.
+//
+// The colors are not part of the props string; they are carried by the
+// bgcolor, textcolor and bordercolor fields of a Cell.
+type Style struct {
+	Font        string
+	Size        int
+	Bold        bool
+	Italic      bool
+	Underline   bool
+	Alignment   Alignment
+	Borders     Borders
+	Background  Color
+	TextColor   Color
+	BorderColor Color
+}
+
+// Borders holds the border widths of each side; 0 hides the border.
+type Borders struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// DefaultStyle returns the style used when props are empty: font1, size 9,
+// normal weight, left aligned and without borders.
+func DefaultStyle() Style {
+	return Style{Font: "font1", Size: 9, Alignment: AlignLeft}
+}
+
+// ParseProps parses a props string with either four parts (font only) or
+// eight parts (font and borders).
+func ParseProps(props string) (Style, error) {
+	parts := strings.Split(props, ":")
+	if len(parts) != 4 && len(parts) != 8 {
+		return Style{}, fmt.Errorf("%w: %q: expected 4 or 8 colon-separated parts, got %d", ErrInvalidProps, props, len(parts))
+	}
+
+	s := Style{Font: parts[0]}
+	if s.Font == "" {
+		return Style{}, fmt.Errorf("%w: %q: font is empty", ErrInvalidProps, props)
+	}
+
+	size, err := strconv.Atoi(parts[1])
+	if err != nil || size <= 0 {
+		return Style{}, fmt.Errorf("%w: %q: invalid font size %q", ErrInvalidProps, props, parts[1])
+	}
+	s.Size = size
+
+	weight := parts[2]
+	if len(weight) != 3 || strings.Trim(weight, "01") != "" {
+		return Style{}, fmt.Errorf("%w: %q: weight %q must be three 0/1 flags", ErrInvalidProps, props, weight)
+	}
+	s.Bold, s.Italic, s.Underline = weight[0] == '1', weight[1] == '1', weight[2] == '1'
+
+	switch a := Alignment(parts[3]); a {
+	case AlignLeft, AlignCenter, AlignRight, AlignJustify:
+		s.Alignment = a
+	default:
+		return Style{}, fmt.Errorf("%w: %q: unknown alignment %q", ErrInvalidProps, props, parts[3])
+	}
+
+	if len(parts) == 8 {
+		sides := []*int{&s.Borders.Top, &s.Borders.Right, &s.Borders.Bottom, &s.Borders.Left}
+		for i, side := range sides {
+			w, err := strconv.Atoi(parts[4+i])
+			if err != nil || w < 0 {
+				return Style{}, fmt.Errorf("%w: %q: invalid border width %q", ErrInvalidProps, props, parts[4+i])
+			}
+			*side = w
+		}
+	}
+	return s, nil
+}
+
+// String returns the eight-part props string. Colors are not included.
+// An empty Font or Alignment and a Size below 1 are replaced by those of
+// DefaultStyle, so that a partial Style such as Style{Size: 12} yields valid
+// props.
+func (s Style) String() string {
+	return fmt.Sprintf("%s:%d:%d:%d:%d",
+		s.FontString(), s.Borders.Top, s.Borders.Right, s.Borders.Bottom, s.Borders.Left)
+}
+
+// FontString returns the four-part props string used by Footer.Font, with
+// the defaults of String.
+func (s Style) FontString() string {
+	def := DefaultStyle()
+	if s.Font == "" {
+		s.Font = def.Font
+	}
+	if s.Size <= 0 {
+		s.Size = def.Size
+	}
+	if s.Alignment == "" {
+		s.Alignment = def.Alignment
+	}
+	return fmt.Sprintf("%s:%d:%s:%s", s.Font, s.Size, s.weight(), s.Alignment)
+}
+
+func (s Style) weight() string {
+	flag := func(b bool) byte {
+		if b {
+			return '1'
+		}
+		return '0'
+	}
+	return string([]byte{flag(s.Bold), flag(s.Italic), flag(s.Underline)})
+}
+
+// styleJSON is the JSON form of a Style with colors, using the color field
+// names of Cell.
+type styleJSON struct {
+	Props       string `json:"props"`
+	BgColor     Color  `json:"bgcolor,omitempty"`
+	TextColor   Color  `json:"textcolor,omitempty"`
+	BorderColor Color  `json:"bordercolor,omitempty"`
+}
+
+// MarshalJSON encodes the style as its props string, or, when it has
+// colors, as an object with the props string in "props" and the colors in
+// "bgcolor", "textcolor" and "bordercolor".
+func (s Style) MarshalJSON() ([]byte, error) {
+	if s.Background == "" && s.TextColor == "" && s.BorderColor == "" {
+		return json.Marshal(s.String())
+	}
+	return json.Marshal(styleJSON{
+		Props:       s.String(),
+		BgColor:     s.Background,
+		TextColor:   s.TextColor,
+		BorderColor: s.BorderColor,
+	})
+}
+
+// UnmarshalJSON decodes the style from either form written by MarshalJSON.
+func (s *Style) UnmarshalJSON(data []byte) error {
+	var in styleJSON
+	if err := json.Unmarshal(data, &in.Props); err != nil {
+		if err := json.Unmarshal(data, &in); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseProps(in.Props)
+	if err != nil {
+		return err
+	}
+	parsed.Background, parsed.TextColor, parsed.BorderColor = in.BgColor, in.TextColor, in.BorderColor
+	*s = parsed
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStyleJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{
+			name:  "props only",
+			style: Style{Font: "font1", Size: 9, Bold: true, Alignment: AlignLeft, Borders: Borders{1, 1, 1, 1}},
+			want:  `"font1:9:100:left:1:1:1:1"`,
+		},
+		{
+			name:  "colors",
+			style: Style{Font: "font2", Size: 12, Alignment: AlignRight, Background: "#EEEEEE", TextColor: "#CC0000"},
+			want:  `{"props":"font2:12:000:right:0:0:0:0","bgcolor":"#EEEEEE","textcolor":"#CC0000"}`,
+		},
+		{
+			name:  "border color",
+			style: Style{Font: "font1", Size: 9, Alignment: AlignCenter, Borders: Borders{0, 0, 2, 0}, BorderColor: "#336699"},
+			want:  `{"props":"font1:9:000:center:0:0:2:0","bordercolor":"#336699"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.style)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+			var back Style
+			if err := json.Unmarshal(data, &back); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if back != tt.style {
+				t.Errorf("round trip = %+v, want %+v", back, tt.style)
+			}
+		})
+	}
+
+	for _, in := range []string{`"font1:9"`, `{"props":"font1:x:000:left"}`, `{"bgcolor":"#FFFFFF"}`, `42`} {
+		var s Style
+		if err := json.Unmarshal([]byte(in), &s); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", in)
+		}
+	}
+}
+
+func TestStyleString(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"zero", Style{}, "font1:9:000:left:0:0:0:0"},
+		{"size only", Style{Size: 12}, "font1:12:000:left:0:0:0:0"},
+		{"weight and borders", Style{Bold: true, Underline: true, Borders: Borders{1, 0, 1, 0}}, "font1:9:101:left:1:0:1:0"},
+		{"alignment only", Style{Alignment: AlignCenter}, "font1:9:000:center:0:0:0:0"},
+		{"font only", Style{Font: "font2"}, "font2:9:000:left:0:0:0:0"},
+		{"negative size", Style{Font: "font2", Size: -3, Alignment: AlignRight}, "font2:9:000:right:0:0:0:0"},
+		{"complete", Style{Font: "font3", Size: 14, Italic: true, Alignment: AlignJustify, Borders: Borders{2, 2, 2, 2}}, "font3:14:010:justify:2:2:2:2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.style.String()
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if _, err := ParseProps(got); err != nil {
+				t.Errorf("ParseProps(String()): %v", err)
+			}
+			if _, err := ParseProps(tt.style.FontString()); err != nil {
+				t.Errorf("ParseProps(FontString()): %v", err)
+			}
+		})
+	}
+}
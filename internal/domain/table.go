@@ -1,21 +1,62 @@
 package domain
 
 // Table represents a table structure in the document.
+//
+// The first HeaderRows rows are header rows, repeated at the top of every
+// page the table continues on. Rows that do not fit on a page are split
+// between lines of text; KeepRowsTogether moves them to the next page
+// instead, unless they are taller than a page. KeepWithPrevious keeps the
+// start of the table on the same page as the preceding table, such as a
+// section heading. Anchor names
+// the position of the table as a target of internal links and bookmarks.
 type Table struct {
-	MaxColumns   int       `json:"maxcolumns"`
-	ColumnWidths []float64 `json:"columnwidths"`
-	Rows         []Row     `json:"rows"`
+	Anchor           string    `json:"anchor,omitempty"`
+	MaxColumns       int       `json:"maxcolumns"`
+	ColumnWidths     []float64 `json:"columnwidths"`
+	HeaderRows       int       `json:"headerrows,omitempty"`
+	KeepRowsTogether bool      `json:"keeprowstogether,omitempty"`
+	KeepWithPrevious bool      `json:"keepwithprevious,omitempty"`
+	Rows             []Row     `json:"rows"`
 }
 
-// Row represents a row in a table.
+// Row represents a row in a table. Height is in whole points; the service
+// reads it as an integer.
 type Row struct {
 	Height int    `json:"height,omitempty"`
 	Cells  []Cell `json:"row"`
 }
 
-// Cell represents a cell in a table row.
+// Cell represents a cell in a table row. A cell with an Image shows the
+// image, scaled to fit the cell, instead of its text. A cell with Runs shows
+// them instead of Text, which then holds their plain text for services that
+// do not support runs. Link makes the whole cell clickable; it is an
+// absolute URL or an internal link made with AnchorLink.
 type Cell struct {
-	Props     string     `json:"props"`
-	Text      string     `json:"text"`
-	FormField *FormField `json:"form_field,omitempty"`
+	Props       string     `json:"props"`
+	Text        string     `json:"text"`
+	Runs        []TextRun  `json:"runs,omitempty"`
+	FormField   *FormField `json:"form_field,omitempty"`
+	BgColor     Color      `json:"bgcolor,omitempty"`
+	TextColor   Color      `json:"textcolor,omitempty"`
+	BorderColor Color      `json:"bordercolor,omitempty"`
+	ColSpan     int        `json:"colspan,omitempty"`
+	RowSpan     int        `json:"rowspan,omitempty"`
+	Image       *Image     `json:"image,omitempty"`
+	Link        string     `json:"link,omitempty"`
+}
+
+// Style parses the cell props into a typed Style, including the cell colors.
+func (c Cell) Style() (Style, error) {
+	s, err := ParseProps(c.Props)
+	if err != nil {
+		return Style{}, err
+	}
+	s.Background, s.TextColor, s.BorderColor = c.BgColor, c.TextColor, c.BorderColor
+	return s, nil
+}
+
+// SetStyle sets the cell props and colors from a typed Style.
+func (c *Cell) SetStyle(s Style) {
+	c.Props = s.String()
+	c.BgColor, c.TextColor, c.BorderColor = s.Background, s.TextColor, s.BorderColor
 }
@@ -0,0 +1,736 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationIssue is a single problem found by Document.Validate.
+// Path addresses the offending value using the document's JSON field names,
+// for example "table[3].rows[0].row[2].props".
+type ValidationIssue struct {
+	Path    string
+	Message string
+}
+
+// String returns the issue as "path: message".
+func (i ValidationIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// ValidationErrors is the list of issues found in a document. It implements
+// error and matches ErrInvalidDocument with errors.Is.
+type ValidationErrors []ValidationIssue
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, issue := range e {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidDocument, strings.Join(msgs, "; "))
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrInvalidDocument
+}
+
+// Validate checks the document for structural problems before it is sent.
+// It returns nil when the document is valid, and otherwise a
+// ValidationErrors listing every issue found.
+func (d *Document) Validate() error {
+	v := &validator{radios: make(map[string]*radioGroupState)}
+	if d.Metadata != nil {
+		v.metadata("metadata", *d.Metadata)
+	}
+	v.config("config", d.Config)
+	v.title("title", d.Title)
+	for i, table := range d.Tables {
+		v.table(fmt.Sprintf("table[%d]", i), table)
+	}
+	v.tables = len(d.Tables)
+	v.placed = make(map[int]bool)
+	v.blocks("elements", d.Body, false)
+	for i, image := range d.Images {
+		v.image(fmt.Sprintf("image[%d]", i), image)
+	}
+	for i, shape := range d.Shapes {
+		v.shape(fmt.Sprintf("shapes[%d]", i), shape)
+	}
+	for i, box := range d.TextBoxes {
+		v.textBox(fmt.Sprintf("textboxes[%d]", i), box)
+	}
+	if d.Header != nil {
+		if d.Header.Font != "" {
+			v.props("header.font", d.Header.Font)
+		}
+		v.pageScope("header.show", d.Header.Show)
+	}
+	if d.Footer.Font != "" {
+		v.props("footer.font", d.Footer.Font)
+	}
+	v.pageScope("footer.show", d.Footer.Show)
+	if d.Config.Signature != nil {
+		v.signature("config.signature", d.Config, d.FormFields())
+	}
+	v.radioGroups()
+	v.bookmarks("bookmarks", d.Bookmarks)
+	v.internalLinks()
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return v.issues
+}
+
+// validator accumulates issues while walking a document.
+type validator struct {
+	issues     ValidationErrors
+	fieldNames map[string]string
+	tabIndexes map[int]string
+	radios     map[string]*radioGroupState
+	radioOrder []string
+	anchors    map[string]string
+	links      []anchorRef  // checked once all anchors are known
+	tables     int          // the number of tables TableRef blocks may refer to
+	placed     map[int]bool // the tables a TableRef already placed
+}
+
+// anchorRef is a reference to a named anchor by an internal link or a
+// bookmark.
+type anchorRef struct {
+	path   string
+	anchor string
+}
+
+// radioGroupState tracks the options of one radio group.
+type radioGroupState struct {
+	path    string
+	checked int
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) metadata(path string, m Metadata) {
+	if m.Language != "" && !languagePattern.MatchString(m.Language) {
+		v.add(path+".language", "invalid language tag %q", m.Language)
+	}
+	names := make([]string, 0, len(m.Custom))
+	for name := range m.Custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !ValidCustomKey(name) {
+			v.add(fmt.Sprintf("%s.custom[%q]", path, name), "invalid or reserved property name")
+		}
+	}
+}
+
+func (v *validator) config(path string, c Config) {
+	switch PageSize(c.Page) {
+	case "", PageSizeA4, PageSizeLetter, PageSizeLegal:
+		if c.Page != "" && (c.PageWidth != 0 || c.PageHeight != 0) {
+			v.add(path+".page", "pageWidth and pageHeight require page %q, got %q", PageSizeCustom, c.Page)
+		}
+	case PageSizeCustom:
+	default:
+		v.add(path+".page", "unknown page size %q", c.Page)
+	}
+	if c.Page == string(PageSizeCustom) || (c.Page == "" && (c.PageWidth != 0 || c.PageHeight != 0)) {
+		if c.PageWidth <= 0 {
+			v.add(path+".pageWidth", "must be positive for a custom page, got %v", c.PageWidth)
+		}
+		if c.PageHeight <= 0 {
+			v.add(path+".pageHeight", "must be positive for a custom page, got %v", c.PageHeight)
+		}
+	}
+	switch c.Orientation {
+	case "", OrientationPortrait, OrientationLandscape:
+	default:
+		v.add(path+".orientation", "unknown orientation %q", c.Orientation)
+	}
+	if m := c.Margins; m != nil {
+		if m.Top < 0 || m.Right < 0 || m.Bottom < 0 || m.Left < 0 {
+			v.add(path+".margins", "must not be negative")
+		} else if w, h, err := c.PageDimensions(); err == nil && (m.Left+m.Right >= w || m.Top+m.Bottom >= h) {
+			v.add(path+".margins", "leave no room for content on a %v x %v page", w, h)
+		}
+	}
+	if c.PageBorder != "" {
+		parts := strings.Split(c.PageBorder, ":")
+		if len(parts) != 4 {
+			v.add(path+".pageBorder", "expected top:right:bottom:left, got %q", c.PageBorder)
+		} else {
+			for _, part := range parts {
+				if w, err := strconv.Atoi(part); err != nil || w < 0 {
+					v.add(path+".pageBorder", "invalid border width %q in %q", part, c.PageBorder)
+					break
+				}
+			}
+		}
+	}
+	if c.Security != nil {
+		v.security(path+".security", *c.Security)
+	}
+}
+
+// signature checks that a signature names an existing signature field and
+// is not combined with encryption, which the signer does not support.
+func (v *validator) signature(path string, c Config, fields []FormField) {
+	if c.Security != nil {
+		v.add(path, "cannot be combined with security settings")
+	}
+	name := c.Signature.Field
+	if name == "" {
+		return
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			if f.Type != FormFieldSignature {
+				v.add(path+".field", "field %q is a %s field, not a signature field", name, f.Type)
+			}
+			return
+		}
+	}
+	v.add(path+".field", "no signature field named %q", name)
+}
+
+// security checks the encryption algorithm and the passwords. Password
+// values never appear in the messages.
+func (v *validator) security(path string, s Security) {
+	alg := s.Algorithm()
+	switch alg {
+	case EncryptionAES128, EncryptionAES256:
+	default:
+		v.add(path+".encryption", "unknown encryption %q, expected %q or %q", s.Encryption, EncryptionAES128, EncryptionAES256)
+		return
+	}
+	for _, pw := range []struct {
+		key   string
+		value Secret
+	}{{"userPassword", s.UserPassword}, {"ownerPassword", s.OwnerPassword}} {
+		value := pw.value.Reveal()
+		if alg == EncryptionAES128 {
+			for _, r := range value {
+				if r > 0xff {
+					v.add(path+"."+pw.key, "must use Latin-1 characters with %s", alg)
+					break
+				}
+			}
+			if n := utf8.RuneCountInString(value); n > 32 {
+				v.add(path+"."+pw.key, "is %d characters long, the limit with %s is 32", n, alg)
+			}
+		} else if len(value) > 127 {
+			v.add(path+"."+pw.key, "is %d bytes long, the limit with %s is 127", len(value), alg)
+		}
+	}
+	if s.OwnerPassword != "" && s.OwnerPassword == s.UserPassword {
+		v.add(path+".ownerPassword", "must differ from the user password, or the permissions have no effect")
+	}
+}
+
+func (v *validator) pageScope(path string, s PageScope) {
+	switch s {
+	case "", ShowAllPages, ShowFirstPageOnly, ShowExceptFirstPage:
+	default:
+		v.add(path, "unknown page scope %q", s)
+	}
+}
+
+func (v *validator) title(path string, t Title) {
+	v.props(path+".props", t.Props)
+	if t.Table != nil {
+		v.table(path+".table", *t.Table)
+	}
+}
+
+// props checks a props string. Empty props select DefaultStyle.
+func (v *validator) props(path, props string) {
+	if props == "" {
+		return
+	}
+	if _, err := ParseProps(props); err != nil {
+		v.add(path, "%v", err)
+	}
+}
+
+func (v *validator) table(path string, t Table) {
+	if t.Anchor != "" {
+		v.anchor(path+".anchor", t.Anchor)
+	}
+	if t.MaxColumns <= 0 {
+		v.add(path+".maxcolumns", "must be positive, got %d", t.MaxColumns)
+	}
+	if len(t.ColumnWidths) != t.MaxColumns {
+		v.add(path+".columnwidths", "has %d widths for %d columns", len(t.ColumnWidths), t.MaxColumns)
+	}
+	for i, w := range t.ColumnWidths {
+		if w <= 0 {
+			v.add(fmt.Sprintf("%s.columnwidths[%d]", path, i), "must be positive, got %g", w)
+		}
+	}
+	for r, row := range t.Rows {
+		rowPath := fmt.Sprintf("%s.rows[%d]", path, r)
+		if row.Height < 0 {
+			v.add(rowPath+".height", "must not be negative, got %v", row.Height)
+		}
+		for c, cell := range row.Cells {
+			v.cell(fmt.Sprintf("%s.row[%d]", rowPath, c), cell)
+		}
+	}
+
+	placements, problems := t.Place()
+	v.headerRows(path, t, placements)
+	for _, p := range problems {
+		if p.Cell < 0 {
+			v.add(fmt.Sprintf("%s.rows[%d].row", path, p.Row), "%s", p.Message)
+		} else {
+			v.add(fmt.Sprintf("%s.rows[%d].row[%d]", path, p.Row, p.Cell), "%s", p.Message)
+		}
+	}
+}
+
+// blocks checks the blocks of the body or of a group. Page breaks inside a
+// group contradict keeping it together, and each table is placed once.
+func (v *validator) blocks(path string, blocks []Block, inGroup bool) {
+	for i, b := range blocks {
+		blockPath := fmt.Sprintf("%s[%d]", path, i)
+		switch b := normalizeBlock(b).(type) {
+		case TableRef:
+			switch {
+			case b.Index < 0 || b.Index >= v.tables:
+				v.add(blockPath+".index", "must be the index of one of the %d tables, got %d", v.tables, b.Index)
+			case v.placed[b.Index]:
+				v.add(blockPath+".index", "table %d is already placed", b.Index)
+			}
+			v.placed[b.Index] = true
+		case Table:
+			v.table(blockPath, b)
+		case PageBreak:
+			if inGroup {
+				v.add(blockPath, "page breaks are not allowed inside a group")
+			}
+		case Spacer:
+			if b.Height <= 0 {
+				v.add(blockPath+".height", "must be positive, got %v", b.Height)
+			}
+		case Group:
+			if len(b.Blocks) == 0 {
+				v.add(blockPath+".elements", "must not be empty")
+			}
+			v.blocks(blockPath+".elements", b.Blocks, true)
+		case Paragraph:
+			if b.Props != "" {
+				v.props(blockPath+".props", b.Props)
+			}
+			v.color(blockPath+".textcolor", b.TextColor)
+			v.link(blockPath+".link", b.Link)
+		case Heading:
+			if b.Level < MinHeadingLevel || b.Level > MaxHeadingLevel {
+				v.add(blockPath+".level", "must be between %d and %d, got %d", MinHeadingLevel, MaxHeadingLevel, b.Level)
+			}
+			if b.Text == "" {
+				v.add(blockPath+".text", "must not be empty")
+			}
+			if b.Props != "" {
+				v.props(blockPath+".props", b.Props)
+			}
+			v.color(blockPath+".textcolor", b.TextColor)
+		case List:
+			v.list(blockPath, b)
+		case TableOfContents:
+			if b.Depth < 0 || b.Depth > MaxHeadingLevel {
+				v.add(blockPath+".depth", "must be between 0 and %d, got %d", MaxHeadingLevel, b.Depth)
+			}
+			if b.TitleProps != "" {
+				v.props(blockPath+".titleprops", b.TitleProps)
+			}
+			if b.Props != "" {
+				v.props(blockPath+".props", b.Props)
+			}
+		case nil:
+			v.add(blockPath, "must not be nil")
+		default:
+			v.add(blockPath, "unsupported block type %T", b)
+		}
+	}
+}
+
+// list checks a list and its nested lists.
+func (v *validator) list(path string, l List) {
+	if l.Props != "" {
+		v.props(path+".props", l.Props)
+	}
+	if len(l.Items) == 0 {
+		v.add(path+".items", "must not be empty")
+	}
+	for i, item := range l.Items {
+		if item.List != nil {
+			v.list(fmt.Sprintf("%s.items[%d].list", path, i), *item.List)
+		}
+	}
+}
+
+// headerRows checks that the header rows exist and can be repeated on every
+// page: they may not hold form fields, which would be duplicated, or cells
+// spanning into the body rows.
+func (v *validator) headerRows(path string, t Table, placements [][]Placement) {
+	if t.HeaderRows < 0 {
+		v.add(path+".headerrows", "must not be negative, got %d", t.HeaderRows)
+		return
+	}
+	if t.HeaderRows > len(t.Rows) {
+		v.add(path+".headerrows", "is %d but the table has %d rows", t.HeaderRows, len(t.Rows))
+		return
+	}
+	for r := 0; r < t.HeaderRows; r++ {
+		for c, cell := range t.Rows[r].Cells {
+			cellPath := fmt.Sprintf("%s.rows[%d].row[%d]", path, r, c)
+			if cell.FormField != nil {
+				v.add(cellPath+".form_field", "header rows are repeated on every page and cannot hold form fields")
+			}
+			if r < len(placements) && c < len(placements[r]) && r+placements[r][c].RowSpan > t.HeaderRows {
+				v.add(cellPath+".rowspan", "spans past the last header row")
+			}
+		}
+	}
+}
+
+func (v *validator) cell(path string, c Cell) {
+	v.props(path+".props", c.Props)
+	v.color(path+".bgcolor", c.BgColor)
+	v.color(path+".textcolor", c.TextColor)
+	v.color(path+".bordercolor", c.BorderColor)
+	if c.FormField != nil {
+		v.formField(path+".form_field", *c.FormField)
+	}
+	if c.Image != nil {
+		v.image(path+".image", *c.Image)
+	}
+	v.link(path+".link", c.Link)
+	if len(c.Runs) > 0 && (c.FormField != nil || c.Image != nil) {
+		v.add(path+".runs", "runs are not shown in cells with a form field or image")
+	}
+	for i, r := range c.Runs {
+		v.run(fmt.Sprintf("%s.runs[%d]", path, i), r)
+	}
+}
+
+// run checks the style and link of a text run.
+func (v *validator) run(path string, r TextRun) {
+	if r.Style != nil {
+		v.props(path+".props", r.Style.FontString())
+		v.color(path+".textcolor", r.Style.TextColor)
+	}
+	v.link(path+".link", r.Link)
+}
+
+// anchor records a named anchor, which must be unique.
+func (v *validator) anchor(path, name string) {
+	if strings.HasPrefix(name, "#") {
+		v.add(path, "must not start with '#', got %q", name)
+		return
+	}
+	if v.anchors == nil {
+		v.anchors = make(map[string]string)
+	}
+	if first, ok := v.anchors[name]; ok {
+		v.add(path, "duplicate anchor %q, first used at %s", name, first)
+	} else {
+		v.anchors[name] = path
+	}
+}
+
+// link checks a link target: an absolute URL, or an internal link whose
+// anchor is checked by internalLinks.
+func (v *validator) link(path, link string) {
+	if link == "" {
+		return
+	}
+	if anchor, ok := LinkAnchor(link); ok {
+		v.links = append(v.links, anchorRef{path: path, anchor: anchor})
+		return
+	}
+	if u, err := url.Parse(link); err != nil || u.Scheme == "" {
+		v.add(path, "must be an absolute URL or an anchor link, got %q", link)
+	}
+}
+
+// internalLinks checks that every internal link and bookmark points to an
+// anchor.
+func (v *validator) internalLinks() {
+	for _, ref := range v.links {
+		if _, ok := v.anchors[ref.anchor]; !ok {
+			v.add(ref.path, "unknown anchor %q", ref.anchor)
+		}
+	}
+}
+
+// bookmarks checks the titles and anchors of the outline.
+func (v *validator) bookmarks(path string, bookmarks []Bookmark) {
+	for i, b := range bookmarks {
+		bookmarkPath := fmt.Sprintf("%s[%d]", path, i)
+		if b.Title == "" {
+			v.add(bookmarkPath+".title", "must not be empty")
+		}
+		if b.Anchor == "" {
+			v.add(bookmarkPath+".anchor", "must not be empty")
+		} else {
+			v.links = append(v.links, anchorRef{path: bookmarkPath + ".anchor", anchor: b.Anchor})
+		}
+		v.bookmarks(bookmarkPath+".children", b.Children)
+	}
+}
+
+func (v *validator) color(path string, c Color) {
+	if c == "" {
+		return
+	}
+	if _, err := ParseColor(string(c)); err != nil {
+		v.add(path, "%v", err)
+	}
+}
+
+func (v *validator) formField(path string, f FormField) {
+	switch f.Type {
+	case FormFieldText, FormFieldCheckbox, FormFieldRadio, FormFieldMultiline, FormFieldSignature:
+	case FormFieldDropdown, FormFieldListbox:
+		v.choiceField(path, f)
+	case FormFieldDate:
+		v.dateField(path, f)
+	default:
+		v.add(path+".type", "unknown form field type %q", f.Type)
+	}
+
+	if f.Name == "" {
+		v.add(path+".name", "is required")
+	} else {
+		if v.fieldNames == nil {
+			v.fieldNames = make(map[string]string)
+		}
+		if first, ok := v.fieldNames[f.Name]; ok {
+			v.add(path+".name", "duplicate field name %q, first used at %s", f.Name, first)
+		} else {
+			v.fieldNames[f.Name] = path + ".name"
+		}
+	}
+
+	v.fieldAttributes(path, f)
+
+	if f.Type == FormFieldRadio {
+		group := f.GroupName
+		if group == "" {
+			group = f.Name
+		}
+		state, ok := v.radios[group]
+		if !ok {
+			state = &radioGroupState{path: path + ".group_name"}
+			v.radios[group] = state
+			v.radioOrder = append(v.radioOrder, group)
+		}
+		if f.Checked {
+			state.checked++
+		}
+	}
+}
+
+// fieldAttributes checks the tab index, length limit and pattern of a field.
+func (v *validator) fieldAttributes(path string, f FormField) {
+	switch {
+	case f.TabIndex < 0:
+		v.add(path+".tab_index", "must not be negative")
+	case f.TabIndex > 0:
+		if v.tabIndexes == nil {
+			v.tabIndexes = make(map[int]string)
+		}
+		if first, ok := v.tabIndexes[f.TabIndex]; ok {
+			v.add(path+".tab_index", "duplicate tab index %d, first used at %s", f.TabIndex, first)
+		} else {
+			v.tabIndexes[f.TabIndex] = path + ".tab_index"
+		}
+	}
+
+	if !f.IsText() {
+		if f.MaxLength != 0 {
+			v.add(path+".max_length", "is only supported by text, multiline and date fields")
+		}
+		if f.Pattern != "" {
+			v.add(path+".pattern", "is only supported by text, multiline and date fields")
+		}
+		return
+	}
+
+	values := fieldValues(f)
+	switch {
+	case f.MaxLength < 0:
+		v.add(path+".max_length", "must not be negative")
+	case f.MaxLength > 0:
+		for _, val := range values {
+			if n := utf8.RuneCountInString(val.value); n > f.MaxLength {
+				v.add(path+"."+val.key, "has %d characters, more than max_length %d", n, f.MaxLength)
+			}
+		}
+	}
+
+	re, err := f.PatternRegexp()
+	if err != nil {
+		v.add(path+".pattern", "%v", err)
+		return
+	}
+	if re == nil {
+		return
+	}
+	for _, val := range values {
+		if val.value != "" && !re.MatchString(val.value) {
+			v.add(path+"."+val.key, "%q does not match pattern %q", val.value, f.Pattern)
+		}
+	}
+}
+
+// fieldValue is a field value with its JSON key.
+type fieldValue struct{ key, value string }
+
+// fieldValues returns the initial and default values of a field.
+func fieldValues(f FormField) []fieldValue {
+	return []fieldValue{{"value", f.Value}, {"default_value", f.DefaultValue}}
+}
+
+func (v *validator) choiceField(path string, f FormField) {
+	if len(f.Options) == 0 {
+		v.add(path+".options", "a %s needs at least one option", f.Type)
+	}
+	options := make(map[string]bool, len(f.Options))
+	for i, o := range f.Options {
+		if options[o] {
+			v.add(fmt.Sprintf("%s.options[%d]", path, i), "duplicate option %q", o)
+		}
+		options[o] = true
+	}
+
+	switch {
+	case len(f.Selected) > 0 && !(f.Type == FormFieldListbox && f.MultiSelect):
+		v.add(path+".selected", "requires a list box with multi_select; use value for a single selection")
+	case f.MultiSelect && f.Type != FormFieldListbox:
+		v.add(path+".multi_select", "is only supported by list boxes")
+	case f.Editable && f.Type != FormFieldDropdown:
+		v.add(path+".editable", "is only supported by dropdowns")
+	}
+
+	if f.Value != "" && !options[f.Value] && !f.Editable {
+		v.add(path+".value", "%q is not one of the options", f.Value)
+	}
+	if f.DefaultValue != "" && !options[f.DefaultValue] && !f.Editable {
+		v.add(path+".default_value", "%q is not one of the options", f.DefaultValue)
+	}
+	for i, s := range f.Selected {
+		if !options[s] {
+			v.add(fmt.Sprintf("%s.selected[%d]", path, i), "%q is not one of the options", s)
+		}
+	}
+}
+
+func (v *validator) dateField(path string, f FormField) {
+	layout, err := DateLayout(f.DateMask())
+	if err != nil {
+		v.add(path+".date_format", "%v", err)
+		return
+	}
+	for _, val := range fieldValues(f) {
+		if val.value == "" {
+			continue
+		}
+		if _, err := time.Parse(layout, val.value); err != nil {
+			v.add(path+"."+val.key, "%q does not match date format %q", val.value, f.DateMask())
+		}
+	}
+}
+
+// radioGroups reports groups with no or several checked options.
+func (v *validator) radioGroups() {
+	for _, group := range v.radioOrder {
+		state := v.radios[group]
+		switch {
+		case state.checked == 0:
+			v.add(state.path, "radio group %q has no checked option", group)
+		case state.checked > 1:
+			v.add(state.path, "radio group %q has %d checked options", group, state.checked)
+		}
+	}
+}
+
+func (v *validator) image(path string, img Image) {
+	switch {
+	case img.Path == "" && img.Data == "":
+		v.add(path, "either path or data is required")
+	case img.Path != "" && img.Data != "":
+		v.add(path, "path and data are mutually exclusive")
+	case img.Data != "":
+		data, err := img.Bytes()
+		if err != nil {
+			v.add(path+".data", "%v", err)
+			break
+		}
+		mimeType, _, _, err := DecodeImageConfig(data)
+		if err != nil {
+			v.add(path+".data", "%v", err)
+		} else if img.MimeType != "" && img.MimeType != mimeType {
+			v.add(path+".mime_type", "is %q but the data is %s", img.MimeType, mimeType)
+		}
+	}
+	if img.Width < 0 || img.Height < 0 {
+		v.add(path, "width and height must not be negative")
+	}
+}
+
+func (v *validator) shape(path string, s Shape) {
+	switch s.Type {
+	case ShapeLine:
+		if s.Width == 0 && s.Height == 0 {
+			v.add(path, "a line needs a non-zero width or height")
+		}
+	case ShapeRect, ShapeRoundRect, ShapeEllipse:
+		if s.Width <= 0 || s.Height <= 0 {
+			v.add(path, "width and height must be positive, got %v by %v", s.Width, s.Height)
+		}
+	default:
+		v.add(path+".type", "unknown shape type %q", s.Type)
+	}
+	if s.Radius < 0 {
+		v.add(path+".radius", "must not be negative, got %v", s.Radius)
+	}
+	if s.StrokeWidth < 0 {
+		v.add(path+".strokewidth", "must not be negative, got %v", s.StrokeWidth)
+	}
+	v.color(path+".stroke", s.Stroke)
+	v.color(path+".fill", s.Fill)
+	v.page(path+".page", s.Page)
+}
+
+func (v *validator) textBox(path string, b TextBox) {
+	if b.Text == "" {
+		v.add(path+".text", "must not be empty")
+	}
+	if b.Props != "" {
+		v.props(path+".props", b.Props)
+	}
+	if b.Width < 0 || b.Height < 0 {
+		v.add(path, "width and height must not be negative")
+	}
+	v.color(path+".bgcolor", b.BgColor)
+	v.color(path+".textcolor", b.TextColor)
+	v.color(path+".bordercolor", b.BorderColor)
+	v.page(path+".page", b.Page)
+}
+
+// page checks the page number of a positioned element.
+func (v *validator) page(path string, page int) {
+	if page < AllPages {
+		v.add(path, "must be a page number or %d for every page, got %d", AllPages, page)
+	}
+}
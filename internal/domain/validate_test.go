@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+const testProps = "font1:9:000:left:1:1:1:1"
+
+// validDocument returns a document without issues: a form table with a text
+// field and a radio group, an image and an A4 page with a border.
+func validDocument() Document {
+	return Document{
+		Config: Config{Page: string(PageSizeA4), PageBorder: "1:1:1:1"},
+		Title:  Title{Props: "font1:14:100:center", Text: "Intake"},
+		Tables: []Table{
+			{
+				MaxColumns:   2,
+				ColumnWidths: []float64{1, 2},
+				Rows: []Row{
+					{Cells: []Cell{
+						{Props: testProps, Text: "Name"},
+						{Props: testProps, FormField: &FormField{Type: FormFieldText, Name: "name"}},
+					}},
+					{Cells: []Cell{
+						{Props: testProps, FormField: &FormField{Type: FormFieldRadio, Name: "yes", GroupName: "consent", Value: "yes", Checked: true}},
+						{Props: testProps, FormField: &FormField{Type: FormFieldRadio, Name: "no", GroupName: "consent", Value: "no"}},
+					}},
+				},
+			},
+		},
+		Images: []Image{{Path: "logo.png", Width: 40, Height: 20}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    func(d *Document)
+		wantPaths []string
+	}{
+		{
+			name:   "valid",
+			change: func(d *Document) {},
+		},
+		{
+			name: "too many cells",
+			change: func(d *Document) {
+				row := &d.Tables[0].Rows[0]
+				row.Cells = append(row.Cells, Cell{Props: testProps, Text: "extra"})
+			},
+			wantPaths: []string{"table[0].rows[0].row[2]"},
+		},
+		{
+			name: "too few cells",
+			change: func(d *Document) {
+				row := &d.Tables[0].Rows[0]
+				row.Cells = row.Cells[:1]
+			},
+			wantPaths: []string{"table[0].rows[0].row"},
+		},
+		{
+			name:      "fewer column widths than columns",
+			change:    func(d *Document) { d.Tables[0].ColumnWidths = []float64{1} },
+			wantPaths: []string{"table[0].columnwidths"},
+		},
+		{
+			name:      "more column widths than columns",
+			change:    func(d *Document) { d.Tables[0].ColumnWidths = []float64{1, 1, 1} },
+			wantPaths: []string{"table[0].columnwidths"},
+		},
+		{
+			name:      "zero and negative column widths",
+			change:    func(d *Document) { d.Tables[0].ColumnWidths = []float64{0, -1} },
+			wantPaths: []string{"table[0].columnwidths[0]", "table[0].columnwidths[1]"},
+		},
+		{
+			name:      "unknown alignment",
+			change:    func(d *Document) { d.Tables[0].Rows[0].Cells[0].Props = "font1:9:000:lefft:1:1:1:1" },
+			wantPaths: []string{"table[0].rows[0].row[0].props"},
+		},
+		{
+			name:      "props with three parts",
+			change:    func(d *Document) { d.Tables[0].Rows[1].Cells[1].Props = "font1:9:000" },
+			wantPaths: []string{"table[0].rows[1].row[1].props"},
+		},
+		{
+			name:      "bad title props",
+			change:    func(d *Document) { d.Title.Props = "font1:0:100:center" },
+			wantPaths: []string{"title.props"},
+		},
+		{
+			name:      "bad footer font",
+			change:    func(d *Document) { d.Footer.Font = "font1:7:0x0:center" },
+			wantPaths: []string{"footer.font"},
+		},
+		{
+			name:      "unknown page",
+			change:    func(d *Document) { d.Config.Page = "A3" },
+			wantPaths: []string{"config.page"},
+		},
+		{
+			name:      "page size with custom dimensions",
+			change:    func(d *Document) { d.Config.PageWidth = 500 },
+			wantPaths: []string{"config.page"},
+		},
+		{
+			name:      "page border with three sides",
+			change:    func(d *Document) { d.Config.PageBorder = "1:1:1" },
+			wantPaths: []string{"config.pageBorder"},
+		},
+		{
+			name:      "page border with a negative width",
+			change:    func(d *Document) { d.Config.PageBorder = "1:-1:1:1" },
+			wantPaths: []string{"config.pageBorder"},
+		},
+		{
+			name:      "page border that is not a number",
+			change:    func(d *Document) { d.Config.PageBorder = "1:1:x:1" },
+			wantPaths: []string{"config.pageBorder"},
+		},
+		{
+			name: "duplicate field name",
+			change: func(d *Document) {
+				d.Tables[0].Rows[0].Cells[0].FormField = &FormField{Type: FormFieldText, Name: "name"}
+			},
+			wantPaths: []string{"table[0].rows[0].row[1].form_field.name"},
+		},
+		{
+			name: "duplicate field name across tables",
+			change: func(d *Document) {
+				d.Title.Table = &Table{MaxColumns: 1, ColumnWidths: []float64{1}, Rows: []Row{{Cells: []Cell{
+					{Props: testProps, FormField: &FormField{Type: FormFieldText, Name: "name"}},
+				}}}}
+			},
+			wantPaths: []string{"table[0].rows[0].row[1].form_field.name"},
+		},
+		{
+			name:      "radio group without a checked option",
+			change:    func(d *Document) { d.Tables[0].Rows[1].Cells[0].FormField.Checked = false },
+			wantPaths: []string{"table[0].rows[1].row[0].form_field.group_name"},
+		},
+		{
+			name:      "radio group with two checked options",
+			change:    func(d *Document) { d.Tables[0].Rows[1].Cells[1].FormField.Checked = true },
+			wantPaths: []string{"table[0].rows[1].row[0].form_field.group_name"},
+		},
+		{
+			name:      "image without a path",
+			change:    func(d *Document) { d.Images[0].Path = "" },
+			wantPaths: []string{"image[0]"},
+		},
+		{
+			name:      "cell image without a path",
+			change:    func(d *Document) { d.Tables[0].Rows[0].Cells[0].Image = &Image{Width: 10, Height: 10} },
+			wantPaths: []string{"table[0].rows[0].row[0].image"},
+		},
+		{
+			name: "table reference out of range",
+			change: func(d *Document) {
+				d.Body = []Block{TableRef{Index: 0}, PageBreak{}, TableRef{Index: 1}}
+			},
+			wantPaths: []string{"elements[2].index"},
+		},
+		{
+			name: "table placed twice",
+			change: func(d *Document) {
+				d.Body = []Block{TableRef{Index: 0}, Group{Blocks: []Block{Spacer{Height: 6}, TableRef{Index: 0}}}}
+			},
+			wantPaths: []string{"elements[1].elements[1].index"},
+		},
+		{
+			name: "page break in a group",
+			change: func(d *Document) {
+				d.Body = []Block{TableRef{Index: 0}, Group{Blocks: []Block{PageBreak{}}}}
+			},
+			wantPaths: []string{"elements[1].elements[0]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := validDocument()
+			tt.change(&doc)
+			err := doc.Validate()
+			if len(tt.wantPaths) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidDocument) {
+				t.Fatalf("Validate error = %v, want %v", err, ErrInvalidDocument)
+			}
+			var issues ValidationErrors
+			if !errors.As(err, &issues) {
+				t.Fatalf("Validate error %T is not ValidationErrors", err)
+			}
+			var paths []string
+			for _, issue := range issues {
+				paths = append(paths, issue.Path)
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("issues at %q, want %q\n%v", paths, tt.wantPaths, err)
+			}
+		})
+	}
+}
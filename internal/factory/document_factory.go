@@ -2,6 +2,8 @@
 package factory
 
 import (
+	"fmt"
+
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/builder"
 	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
 )
@@ -16,6 +18,9 @@ const (
 	DocumentTypeCustom  DocumentType = "custom"
 )
 
+// pageOfPages is the page-number text used by the preset footers.
+const pageOfPages = "Page " + domain.TokenPage + " of " + domain.TokenPages
+
 // RadioOption represents a radio button option.
 type RadioOption struct {
 	Label   string
@@ -31,6 +36,44 @@ type CheckboxOption struct {
 	Checked bool
 }
 
+// FieldOption sets an attribute of a form field added by FormBuilder.
+type FieldOption func(*domain.FormField)
+
+// Required marks the field as required.
+func Required() FieldOption {
+	return func(f *domain.FormField) { f.Required = true }
+}
+
+// ReadOnly makes the field read-only.
+func ReadOnly() FieldOption {
+	return func(f *domain.FormField) { f.ReadOnly = true }
+}
+
+// MaxLength limits the number of characters the field accepts.
+func MaxLength(n int) FieldOption {
+	return func(f *domain.FormField) { f.MaxLength = n }
+}
+
+// Tooltip sets the text shown when the pointer rests on the field.
+func Tooltip(text string) FieldOption {
+	return func(f *domain.FormField) { f.Tooltip = text }
+}
+
+// TabIndex sets the position of the field in the tab order.
+func TabIndex(index int) FieldOption {
+	return func(f *domain.FormField) { f.TabIndex = index }
+}
+
+// DefaultValue sets the value the field resets to.
+func DefaultValue(value string) FieldOption {
+	return func(f *domain.FormField) { f.DefaultValue = value }
+}
+
+// Pattern sets a regular expression the whole field value must match.
+func Pattern(pattern string) FieldOption {
+	return func(f *domain.FormField) { f.Pattern = pattern }
+}
+
 // DocumentFactory creates documents based on templates or configurations.
 type DocumentFactory struct {
 	defaultConfig domain.Config
@@ -60,11 +103,35 @@ func (f *DocumentFactory) CreateDocument(docType DocumentType) domain.DocumentBu
 
 	switch docType {
 	case DocumentTypeForm:
-		return docBuilder.WithFooter("font1:7:000:center", "")
+		return docBuilder.
+			WithHeader(domain.Header{
+				Font:      "font1:7:000:center",
+				PageSlots: domain.PageSlots{Right: domain.TokenDate, Show: domain.ShowFirstPageOnly},
+			}).
+			WithPageFooter(domain.Footer{
+				Font:      "font1:7:000:center",
+				PageSlots: domain.PageSlots{Right: pageOfPages},
+			})
 	case DocumentTypeReport:
-		return docBuilder.WithFooter("font1:8:000:center", "")
+		return docBuilder.
+			WithHeader(domain.Header{
+				Font:      "font1:8:000:center",
+				PageSlots: domain.PageSlots{Left: domain.TokenTitle, Right: domain.TokenDate, Show: domain.ShowExceptFirstPage},
+			}).
+			WithPageFooter(domain.Footer{
+				Font:      "font1:8:000:center",
+				PageSlots: domain.PageSlots{Center: pageOfPages},
+			})
 	case DocumentTypeInvoice:
-		return docBuilder.WithFooter("font1:7:000:right", "")
+		return docBuilder.
+			WithHeader(domain.Header{
+				Font:      "font1:7:000:right",
+				PageSlots: domain.PageSlots{Right: domain.TokenTitle, Show: domain.ShowExceptFirstPage},
+			}).
+			WithPageFooter(domain.Footer{
+				Font:      "font1:7:000:right",
+				PageSlots: domain.PageSlots{Right: pageOfPages},
+			})
 	default:
 		return docBuilder
 	}
@@ -77,8 +144,10 @@ func (f *DocumentFactory) CreateFormBuilder() *FormBuilder {
 
 // FormBuilder provides a specialized builder for form documents.
 type FormBuilder struct {
-	docBuilder domain.DocumentBuilder
-	config     domain.Config
+	docBuilder   domain.DocumentBuilder
+	config       domain.Config
+	afterSection bool
+	sections     int
 }
 
 // NewFormBuilder creates a new FormBuilder.
@@ -96,23 +165,45 @@ func (fb *FormBuilder) WithTitle(text string) *FormBuilder {
 	return fb
 }
 
-// AddSection adds a section header to the form.
+// AddSection adds a section header to the form and a bookmark to it. The
+// header table is anchored as "section-1", "section-2" and so on.
 func (fb *FormBuilder) AddSection(title string) *FormBuilder {
+	fb.sections++
+	anchor := fmt.Sprintf("section-%d", fb.sections)
 	props := builder.NewPropsBuilder().WithSize(10).Bold().Left().AllBorders().Build()
-	table := builder.NewTableBuilder().WithColumns(1, []float64{1}).AddRow(builder.Cell(props, title)).Build()
+	table := builder.NewTableBuilder().
+		WithColumns(1, []float64{1}).
+		WithAnchor(anchor).
+		AddRow(builder.Cell(props, title)).
+		Build()
 	fb.docBuilder.AddTable(table)
+	fb.docBuilder.AddBookmark(domain.Bookmark{Title: title, Anchor: anchor})
+	fb.afterSection = true
 	return fb
 }
 
-// AddTextField adds a text field row to the form.
-func (fb *FormBuilder) AddTextField(label, name, value string) *FormBuilder {
+// addFieldTable adds a table of fields. The first one after a section header
+// is kept on the same page as the header.
+func (fb *FormBuilder) addFieldTable(table domain.Table) {
+	table.KeepWithPrevious = fb.afterSection
+	fb.afterSection = false
+	fb.docBuilder.AddTable(table)
+}
+
+// AddTextField adds a text field row to the form. Options such as Required
+// or MaxLength set further field attributes.
+func (fb *FormBuilder) AddTextField(label, name, value string, opts ...FieldOption) *FormBuilder {
 	labelProps := builder.NewPropsBuilder().WithSize(9).Bold().Left().AllBorders().Build()
 	valueProps := builder.NewPropsBuilder().WithSize(9).Normal().Left().AllBorders().Build()
+	field := builder.TextFieldCell(valueProps, value, name, value)
+	for _, opt := range opts {
+		opt(field.FormField)
+	}
 	table := builder.NewTableBuilder().
 		WithColumns(2, []float64{1, 3}).
-		AddRow(builder.Cell(labelProps, label), builder.TextFieldCell(valueProps, value, name, value)).
+		AddRow(builder.Cell(labelProps, label), field).
 		Build()
-	fb.docBuilder.AddTable(table)
+	fb.addFieldTable(table)
 	return fb
 }
 
@@ -129,7 +220,17 @@ func (fb *FormBuilder) AddTwoColumnTextField(label1, name1, value1, label2, name
 			builder.TextFieldCell(valueProps, value2, name2, value2),
 		).
 		Build()
-	fb.docBuilder.AddTable(table)
+	fb.addFieldTable(table)
+	return fb
+}
+
+// WithHeader sets the form header slots. The texts may contain page tokens
+// such as domain.TokenPage.
+func (fb *FormBuilder) WithHeader(left, center, right string) *FormBuilder {
+	fb.docBuilder.WithHeader(domain.Header{
+		Font:      "font1:7:000:center",
+		PageSlots: domain.PageSlots{Left: left, Center: center, Right: right},
+	})
 	return fb
 }
 
@@ -144,3 +245,9 @@ func (fb *FormBuilder) Build() *domain.Document {
 	fb.docBuilder.WithConfig(fb.config)
 	return fb.docBuilder.Build()
 }
+
+// Err returns the first error of the underlying document builder, such as
+// two fields with the same tab index.
+func (fb *FormBuilder) Err() error {
+	return fb.docBuilder.Err()
+}
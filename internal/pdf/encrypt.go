@@ -0,0 +1,292 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+)
+
+// Encrypter encrypts the strings and stream data of an indirect object.
+type Encrypter interface {
+	Encrypt(ref Ref, data []byte) []byte
+}
+
+// Permission bits of the standard security handler.
+const (
+	PermPrint        int32 = 1 << 2
+	PermModify       int32 = 1 << 3
+	PermCopy         int32 = 1 << 4
+	PermAnnotate     int32 = 1 << 5
+	PermFillForms    int32 = 1 << 8
+	PermAccessible   int32 = 1 << 9
+	PermAssemble     int32 = 1 << 10
+	PermPrintQuality int32 = 1 << 11
+
+	// permReserved holds the bits that must be set: 7, 8 and 13 to 32.
+	permReserved int32 = -3904 // 0xFFFFF0C0
+)
+
+// passwordPadding pads passwords for the revision 4 algorithms.
+var passwordPadding = []byte{
+	0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
+	0x2E, 0x2E, 0x00, 0xB6, 0xD0, 0x68, 0x3E, 0x80, 0x2F, 0x0C, 0xA9, 0xFE, 0x64, 0x53, 0x69, 0x7A,
+}
+
+// StandardSecurity holds the parameters of the standard security handler.
+// UserPassword and OwnerPassword are UTF-8 for revision 6 and Latin-1 bytes
+// for revision 4. Permissions are the Perm bits to grant.
+type StandardSecurity struct {
+	AES256        bool
+	UserPassword  []byte
+	OwnerPassword []byte
+	Permissions   int32
+}
+
+// standardEncrypter encrypts objects with AES using the file key.
+type standardEncrypter struct {
+	key    []byte
+	aes256 bool
+	rand   io.Reader
+}
+
+// NewFileID returns a random file identifier for the trailer ID entry.
+func NewFileID() ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// Encrypt creates the encryption dictionary for the file identifier id and
+// the Encrypter for the document objects.
+func (s StandardSecurity) Encrypt(id []byte) (Encrypter, Dict, error) {
+	p := s.Permissions | permReserved
+	if s.AES256 {
+		return s.revision6(p)
+	}
+	return s.revision4(p, id)
+}
+
+// revision4 implements 128-bit AES (V 4, R 4).
+func (s StandardSecurity) revision4(p int32, id []byte) (Encrypter, Dict, error) {
+	owner := s.OwnerPassword
+	if len(owner) == 0 {
+		owner = s.UserPassword
+	}
+
+	// Algorithm 3: the O entry.
+	sum := md5.Sum(padPassword(owner))
+	ownerKey := sum[:]
+	for i := 0; i < 50; i++ {
+		sum = md5.Sum(ownerKey)
+		ownerKey = sum[:]
+	}
+	o := padPassword(s.UserPassword)
+	rc4Rounds(ownerKey, o)
+
+	// Algorithm 2: the file key.
+	h := md5.New()
+	h.Write(padPassword(s.UserPassword))
+	h.Write(o)
+	binary.Write(h, binary.LittleEndian, p)
+	h.Write(id)
+	key := h.Sum(nil)
+	for i := 0; i < 50; i++ {
+		sum = md5.Sum(key)
+		key = sum[:]
+	}
+
+	// Algorithm 5: the U entry.
+	h = md5.New()
+	h.Write(passwordPadding)
+	h.Write(id)
+	u := h.Sum(nil)
+	rc4Rounds(key, u)
+	u = append(u, make([]byte, 16)...)
+
+	dict := Dict{
+		"Filter": Name("Standard"),
+		"V":      4,
+		"R":      4,
+		"Length": 128,
+		"CF": Dict{"StdCF": Dict{
+			"CFM":       Name("AESV2"),
+			"AuthEvent": Name("DocOpen"),
+			"Length":    16,
+		}},
+		"StmF": Name("StdCF"),
+		"StrF": Name("StdCF"),
+		"O":    HexString(o),
+		"U":    HexString(u),
+		"P":    int(p),
+	}
+	return &standardEncrypter{key: key, rand: rand.Reader}, dict, nil
+}
+
+// revision6 implements 256-bit AES (V 5, R 6).
+func (s StandardSecurity) revision6(p int32) (Encrypter, Dict, error) {
+	user := truncate(s.UserPassword, 127)
+	owner := truncate(s.OwnerPassword, 127)
+
+	random := make([]byte, 32+16+16+4)
+	if _, err := io.ReadFull(rand.Reader, random); err != nil {
+		return nil, nil, err
+	}
+	key, userSalts, ownerSalts, permsTail := random[:32], random[32:48], random[48:64], random[64:]
+
+	u := append(hashR6(user, userSalts[:8], nil), userSalts...)
+	ue, err := encryptKey(hashR6(user, userSalts[8:], nil), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := append(hashR6(owner, ownerSalts[:8], u), ownerSalts...)
+	oe, err := encryptKey(hashR6(owner, ownerSalts[8:], u), key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perms := make([]byte, 16)
+	binary.LittleEndian.PutUint32(perms, uint32(p))
+	copy(perms[4:], []byte{0xff, 0xff, 0xff, 0xff, 'T', 'a', 'd', 'b'})
+	copy(perms[12:], permsTail)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	block.Encrypt(perms, perms)
+
+	dict := Dict{
+		"Filter": Name("Standard"),
+		"V":      5,
+		"R":      6,
+		"Length": 256,
+		"CF": Dict{"StdCF": Dict{
+			"CFM":       Name("AESV3"),
+			"AuthEvent": Name("DocOpen"),
+			"Length":    32,
+		}},
+		"StmF":  Name("StdCF"),
+		"StrF":  Name("StdCF"),
+		"O":     HexString(o),
+		"U":     HexString(u),
+		"OE":    HexString(oe),
+		"UE":    HexString(ue),
+		"P":     int(p),
+		"Perms": HexString(perms),
+	}
+	return &standardEncrypter{key: key, aes256: true, rand: rand.Reader}, dict, nil
+}
+
+// Encrypt encrypts data with AES-CBC and a random IV prepended to the output.
+func (e *standardEncrypter) Encrypt(ref Ref, data []byte) []byte {
+	key := e.key
+	if !e.aes256 {
+		h := md5.New()
+		h.Write(key)
+		h.Write([]byte{byte(ref.Num), byte(ref.Num >> 8), byte(ref.Num >> 16), byte(ref.Gen), byte(ref.Gen >> 8)})
+		h.Write([]byte("sAlT"))
+		key = h.Sum(nil)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err) // the key length is always valid
+	}
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	out := make([]byte, aes.BlockSize+len(data)+pad)
+	if _, err := io.ReadFull(e.rand, out[:aes.BlockSize]); err != nil {
+		panic(err)
+	}
+	copy(out[aes.BlockSize:], data)
+	copy(out[aes.BlockSize+len(data):], bytes.Repeat([]byte{byte(pad)}, pad))
+	cipher.NewCBCEncrypter(block, out[:aes.BlockSize]).CryptBlocks(out[aes.BlockSize:], out[aes.BlockSize:])
+	return out
+}
+
+// padPassword pads or truncates a password to 32 bytes.
+func padPassword(pw []byte) []byte {
+	out := make([]byte, 32)
+	n := copy(out, pw)
+	copy(out[n:], passwordPadding)
+	return out
+}
+
+// rc4Rounds applies the 20 RC4 rounds of algorithms 3 and 5 in place.
+func rc4Rounds(key, data []byte) {
+	k := make([]byte, len(key))
+	for i := 0; i < 20; i++ {
+		for j := range key {
+			k[j] = key[j] ^ byte(i)
+		}
+		c, _ := rc4.NewCipher(k)
+		c.XORKeyStream(data, data)
+	}
+}
+
+func truncate(b []byte, n int) []byte {
+	if len(b) > n {
+		return b[:n]
+	}
+	return b
+}
+
+// hashR6 is the revision 6 password hash (algorithm 2.B).
+func hashR6(password, salt, userKey []byte) []byte {
+	h := sha256.New()
+	h.Write(password)
+	h.Write(salt)
+	h.Write(userKey)
+	k := h.Sum(nil)
+
+	for i := 0; ; i++ {
+		seq := append(append(append([]byte{}, password...), k...), userKey...)
+		k1 := bytes.Repeat(seq, 64)
+		block, _ := aes.NewCipher(k[:16])
+		e := make([]byte, len(k1))
+		cipher.NewCBCEncrypter(block, k[16:32]).CryptBlocks(e, k1)
+
+		sum := 0
+		for _, b := range e[:16] {
+			sum += int(b)
+		}
+		var next hash.Hash
+		switch sum % 3 {
+		case 0:
+			next = sha256.New()
+		case 1:
+			next = sha512.New384()
+		default:
+			next = sha512.New()
+		}
+		next.Write(e)
+		k = next.Sum(nil)
+
+		if i >= 63 && int(e[len(e)-1]) <= i+1-32 {
+			break
+		}
+	}
+	return k[:32]
+}
+
+// encryptKey encrypts the file key with AES-256 in CBC mode with a zero IV.
+func encryptKey(hashKey, fileKey []byte) ([]byte, error) {
+	block, err := aes.NewCipher(hashKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileKey)%aes.BlockSize != 0 {
+		return nil, errors.New("pdf: file key is not a multiple of the block size")
+	}
+	out := make([]byte, len(fileKey))
+	cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(out, fileKey)
+	return out, nil
+}
@@ -0,0 +1,213 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/binary"
+	"testing"
+)
+
+// decryptKey recovers the file key from the encryption dictionary the way a
+// reader does, authenticating password as the user password or, failing
+// that, as the owner password. It reports false for a wrong password.
+func decryptKey(t *testing.T, dict Dict, id, password []byte) ([]byte, bool) {
+	t.Helper()
+	o, u := []byte(dict["O"].(HexString)), []byte(dict["U"].(HexString))
+	p := int32(dict["P"].(int))
+
+	if dict["R"] == 6 {
+		if bytes.Equal(hashR6(password, u[32:40], nil), u[:32]) {
+			return aesDecryptKey(t, hashR6(password, u[40:48], nil), dict["UE"].(HexString)), true
+		}
+		if bytes.Equal(hashR6(password, o[32:40], u[:48]), o[:32]) {
+			return aesDecryptKey(t, hashR6(password, o[40:48], u[:48]), dict["OE"].(HexString)), true
+		}
+		return nil, false
+	}
+
+	// Algorithms 6 and 7: try the password as the user password, then
+	// recover the user password from O with it as the owner password.
+	candidates := [][]byte{password}
+	sum := md5.Sum(padPassword(password))
+	ownerKey := sum[:]
+	for i := 0; i < 50; i++ {
+		sum = md5.Sum(ownerKey)
+		ownerKey = sum[:]
+	}
+	user := append([]byte{}, o...)
+	k := make([]byte, len(ownerKey))
+	for i := 19; i >= 0; i-- {
+		for j := range ownerKey {
+			k[j] = ownerKey[j] ^ byte(i)
+		}
+		c, _ := rc4.NewCipher(k)
+		c.XORKeyStream(user, user)
+	}
+	candidates = append(candidates, user)
+
+	for _, pw := range candidates {
+		h := md5.New()
+		h.Write(padPassword(pw))
+		h.Write(o)
+		binary.Write(h, binary.LittleEndian, p)
+		h.Write(id)
+		key := h.Sum(nil)
+		for i := 0; i < 50; i++ {
+			sum := md5.Sum(key)
+			key = sum[:]
+		}
+		h = md5.New()
+		h.Write(passwordPadding)
+		h.Write(id)
+		check := h.Sum(nil)
+		rc4Rounds(key, check)
+		if bytes.Equal(check, u[:16]) {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// aesDecryptKey decrypts an OE or UE entry.
+func aesDecryptKey(t *testing.T, hashKey, encrypted []byte) []byte {
+	t.Helper()
+	block, err := aes.NewCipher(hashKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(key, encrypted)
+	return key
+}
+
+// decryptObject decrypts the data of object ref with the file key.
+func decryptObject(t *testing.T, key []byte, aes256 bool, ref Ref, data []byte) []byte {
+	t.Helper()
+	if !aes256 {
+		h := md5.New()
+		h.Write(key)
+		h.Write([]byte{byte(ref.Num), byte(ref.Num >> 8), byte(ref.Num >> 16), byte(ref.Gen), byte(ref.Gen >> 8)})
+		h.Write([]byte("sAlT"))
+		key = h.Sum(nil)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		t.Fatalf("ciphertext length %d is not IV plus whole blocks", len(data))
+	}
+	out := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(out, data[aes.BlockSize:])
+	pad := int(out[len(out)-1])
+	if pad < 1 || pad > aes.BlockSize {
+		t.Fatalf("invalid padding %d", pad)
+	}
+	return out[:len(out)-pad]
+}
+
+func TestStandardSecurityRoundTrip(t *testing.T) {
+	id := []byte("0123456789abcdef")
+	plain := []byte("BT /F1 12 Tf 72 720 Td (Hello, encrypted world) Tj ET")
+	ref := Ref{Num: 7}
+
+	tests := []struct {
+		name     string
+		security StandardSecurity
+		password string
+		ok       bool
+	}{
+		{"R4 user password", StandardSecurity{UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "user", true},
+		{"R4 owner password", StandardSecurity{UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "owner", true},
+		{"R4 empty user password", StandardSecurity{OwnerPassword: []byte("owner")}, "", true},
+		{"R4 owner defaults to user", StandardSecurity{UserPassword: []byte("user")}, "user", true},
+		{"R4 wrong password", StandardSecurity{UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "guess", false},
+		{"R6 user password", StandardSecurity{AES256: true, UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "user", true},
+		{"R6 owner password", StandardSecurity{AES256: true, UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "owner", true},
+		{"R6 empty user password", StandardSecurity{AES256: true, OwnerPassword: []byte("owner")}, "", true},
+		{"R6 UTF-8 password", StandardSecurity{AES256: true, UserPassword: []byte("pässwörd")}, "pässwörd", true},
+		{"R6 wrong password", StandardSecurity{AES256: true, UserPassword: []byte("user"), OwnerPassword: []byte("owner")}, "guess", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, dict, err := tt.security.Encrypt(id)
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			key, ok := decryptKey(t, dict, id, []byte(tt.password))
+			if ok != tt.ok {
+				t.Fatalf("password %q accepted = %v, want %v", tt.password, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+
+			data := enc.Encrypt(ref, plain)
+			if bytes.Contains(data, plain) {
+				t.Fatal("ciphertext contains the plain text")
+			}
+			if got := decryptObject(t, key, tt.security.AES256, ref, data); !bytes.Equal(got, plain) {
+				t.Fatalf("decrypted %q, want %q", got, plain)
+			}
+		})
+	}
+}
+
+func TestStandardSecurityPermissions(t *testing.T) {
+	tests := []struct {
+		name   string
+		aes256 bool
+	}{
+		{"R4", false},
+		{"R6", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted := PermPrint | PermCopy
+			enc, dict, err := StandardSecurity{AES256: tt.aes256, OwnerPassword: []byte("owner"), Permissions: granted}.Encrypt([]byte("0123456789abcdef"))
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			p := int32(dict["P"].(int))
+			if want := granted | permReserved; p != want {
+				t.Fatalf("P = %#x, want %#x", uint32(p), uint32(want))
+			}
+			if p&PermModify != 0 {
+				t.Error("P grants modification")
+			}
+			if !tt.aes256 {
+				return
+			}
+
+			// Perms is the permissions encrypted with the file key, which
+			// readers check against P.
+			key := enc.(*standardEncrypter).key
+			block, err := aes.NewCipher(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			perms := make([]byte, 16)
+			block.Decrypt(perms, dict["Perms"].(HexString))
+			if got := int32(binary.LittleEndian.Uint32(perms)); got != p {
+				t.Errorf("Perms holds P = %#x, want %#x", uint32(got), uint32(p))
+			}
+			if string(perms[9:12]) != "adb" {
+				t.Errorf("Perms marker = %q, want \"adb\"", perms[9:12])
+			}
+		})
+	}
+}
+
+func TestStandardEncrypterFreshIV(t *testing.T) {
+	enc, _, err := StandardSecurity{UserPassword: []byte("user")}.Encrypt([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	ref := Ref{Num: 1}
+	if a, b := enc.Encrypt(ref, []byte("same")), enc.Encrypt(ref, []byte("same")); bytes.Equal(a, b) {
+		t.Error("two encryptions of the same data are identical")
+	}
+}
@@ -0,0 +1,188 @@
+// Package pdf provides a minimal PDF object model and file writer.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Object is any value that can be serialized as a PDF object:
+// nil, bool, int, float64, Name, String, HexString, Ref, Array, Dict, *Stream or Raw.
+type Object interface{}
+
+// Name is a PDF name object, written as /Name.
+type Name string
+
+// String is a PDF literal string. It holds raw bytes, not UTF-8 text.
+type String string
+
+// HexString is a PDF string written in hexadecimal form.
+type HexString []byte
+
+// Raw is written to the output verbatim.
+type Raw string
+
+// Ref is an indirect object reference.
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Array is a PDF array.
+type Array []Object
+
+// Dict is a PDF dictionary. Keys are written in sorted order.
+type Dict map[Name]Object
+
+// Stream is a PDF stream object. The Length entry is set when written.
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+// encoder serializes objects into a buffer. When crypt is set, strings and
+// stream data are encrypted with it.
+type encoder struct {
+	buf   *bytes.Buffer
+	crypt func([]byte) []byte
+}
+
+func (e *encoder) write(obj Object) {
+	switch v := obj.(type) {
+	case nil:
+		e.buf.WriteString("null")
+	case bool:
+		e.buf.WriteString(strconv.FormatBool(v))
+	case int:
+		e.buf.WriteString(strconv.Itoa(v))
+	case int64:
+		e.buf.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		e.buf.WriteString(FormatNumber(v))
+	case Name:
+		writeName(e.buf, string(v))
+	case String:
+		if e.crypt != nil {
+			writeHex(e.buf, e.crypt([]byte(v)))
+		} else {
+			writeLiteral(e.buf, []byte(v))
+		}
+	case HexString:
+		if e.crypt != nil {
+			v = e.crypt(v)
+		}
+		writeHex(e.buf, v)
+	case Raw:
+		e.buf.WriteString(string(v))
+	case Ref:
+		fmt.Fprintf(e.buf, "%d %d R", v.Num, v.Gen)
+	case Array:
+		e.buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				e.buf.WriteByte(' ')
+			}
+			e.write(item)
+		}
+		e.buf.WriteByte(']')
+	case Dict:
+		e.writeDict(v)
+	case *Stream:
+		data := v.Data
+		if e.crypt != nil {
+			data = e.crypt(data)
+		}
+		dict := make(Dict, len(v.Dict)+1)
+		for k, val := range v.Dict {
+			dict[k] = val
+		}
+		dict["Length"] = len(data)
+		e.writeDict(dict)
+		e.buf.WriteString("\nstream\n")
+		e.buf.Write(data)
+		e.buf.WriteString("\nendstream")
+	default:
+		panic(fmt.Sprintf("pdf: unsupported object type %T", obj))
+	}
+}
+
+func (e *encoder) writeDict(d Dict) {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+
+	e.buf.WriteString("<<")
+	for _, k := range keys {
+		e.buf.WriteByte(' ')
+		writeName(e.buf, k)
+		e.buf.WriteByte(' ')
+		e.write(d[Name(k)])
+	}
+	e.buf.WriteString(" >>")
+}
+
+// FormatNumber formats a real number compactly with at most four decimals.
+func FormatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = trimZeros(s)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func trimZeros(s string) string {
+	if !bytes.ContainsRune([]byte(s), '.') {
+		return s
+	}
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func writeName(buf *bytes.Buffer, name string) {
+	buf.WriteByte('/')
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < 0x21 || c > 0x7e || bytes.IndexByte([]byte("#/()<>[]{}%"), c) >= 0 {
+			fmt.Fprintf(buf, "#%02X", c)
+			continue
+		}
+		buf.WriteByte(c)
+	}
+}
+
+func writeLiteral(buf *bytes.Buffer, data []byte) {
+	buf.WriteByte('(')
+	for _, c := range data {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(buf, "\\%03o", c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(')')
+}
+
+func writeHex(buf *bytes.Buffer, data []byte) {
+	fmt.Fprintf(buf, "<%X>", data)
+}
+
+// Literal returns the literal string form of data, for use in content streams.
+func Literal(data []byte) string {
+	var buf bytes.Buffer
+	writeLiteral(&buf, data)
+	return buf.String()
+}
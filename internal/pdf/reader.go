@@ -0,0 +1,723 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Reader gives access to the objects of an existing PDF file through its
+// cross-reference sections, including cross-reference and object streams.
+// Encrypted files can be read, but strings and streams stay encrypted.
+type Reader struct {
+	data      []byte
+	xref      map[int]xrefEntry
+	trailer   Dict
+	startXRef int
+	size      int
+	objStms   map[int]*objStm
+}
+
+// xrefEntry locates an object: at a byte offset, or at an index inside an
+// object stream. Free objects have a negative offset.
+type xrefEntry struct {
+	offset int
+	stream int // object stream number, 0 for uncompressed objects
+	index  int
+}
+
+// objStm is a decoded object stream with the offsets of its objects.
+type objStm struct {
+	data    []byte
+	offsets []int
+}
+
+// NewReader parses the cross-reference sections of a PDF file.
+func NewReader(data []byte) (*Reader, error) {
+	r := &Reader{data: data, xref: make(map[int]xrefEntry), objStms: make(map[int]*objStm)}
+	start, err := findStartXRef(data)
+	if err != nil {
+		return nil, err
+	}
+	r.startXRef = start
+
+	seen := make(map[int]bool)
+	for offset := start; ; {
+		if seen[offset] {
+			return nil, errors.New("pdf: cross-reference sections form a loop")
+		}
+		seen[offset] = true
+		trailer, err := r.readXRef(offset)
+		if err != nil {
+			return nil, err
+		}
+		if r.trailer == nil {
+			r.trailer = trailer
+		}
+		if stm, ok := trailer["XRefStm"].(int); ok && !seen[stm] {
+			seen[stm] = true
+			if _, err := r.readXRef(stm); err != nil {
+				return nil, err
+			}
+		}
+		prev, ok := trailer["Prev"].(int)
+		if !ok {
+			break
+		}
+		offset = prev
+	}
+
+	size, _ := r.trailer["Size"].(int)
+	r.size = size
+	for num := range r.xref {
+		if num >= r.size {
+			r.size = num + 1
+		}
+	}
+	return r, nil
+}
+
+// Trailer returns the trailer dictionary of the last cross-reference section.
+func (r *Reader) Trailer() Dict {
+	return r.trailer
+}
+
+// Size returns the number of objects, one more than the highest object number.
+func (r *Reader) Size() int {
+	return r.size
+}
+
+// StartXRef returns the offset of the last cross-reference section.
+func (r *Reader) StartXRef() int {
+	return r.startXRef
+}
+
+// Data returns the file bytes.
+func (r *Reader) Data() []byte {
+	return r.data
+}
+
+// Object returns the object with the given reference, or nil when it does
+// not exist.
+func (r *Reader) Object(ref Ref) (Object, error) {
+	entry, ok := r.xref[ref.Num]
+	if !ok || entry.offset < 0 {
+		return nil, nil
+	}
+	if entry.stream != 0 {
+		return r.compressedObject(entry)
+	}
+	p := &parser{data: r.data, pos: entry.offset, r: r}
+	if num, err := strconv.Atoi(p.token()); err != nil || num != ref.Num {
+		return nil, fmt.Errorf("pdf: object %d: no object header at offset %d", ref.Num, entry.offset)
+	}
+	p.token() // generation
+	if !p.keyword("obj") {
+		return nil, fmt.Errorf("pdf: object %d: missing obj keyword", ref.Num)
+	}
+	obj, err := p.parseObject()
+	if err != nil {
+		return nil, fmt.Errorf("pdf: object %d: %w", ref.Num, err)
+	}
+	if dict, ok := obj.(Dict); ok && p.keyword("stream") {
+		return p.parseStream(dict)
+	}
+	return obj, nil
+}
+
+// Resolve follows indirect references until it reaches a direct object.
+func (r *Reader) Resolve(obj Object) (Object, error) {
+	for i := 0; i < 32; i++ {
+		ref, ok := obj.(Ref)
+		if !ok {
+			return obj, nil
+		}
+		var err error
+		if obj, err = r.Object(ref); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("pdf: reference chain too long")
+}
+
+// ResolveDict resolves obj and returns it as a dictionary, or nil when it is
+// not one. The dictionary of a stream is returned for streams.
+func (r *Reader) ResolveDict(obj Object) (Dict, error) {
+	obj, err := r.Resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+	switch v := obj.(type) {
+	case Dict:
+		return v, nil
+	case *Stream:
+		return v.Dict, nil
+	}
+	return nil, nil
+}
+
+// ResolveArray resolves obj and returns it as an array, or nil when it is
+// not one.
+func (r *Reader) ResolveArray(obj Object) (Array, error) {
+	obj, err := r.Resolve(obj)
+	if err != nil {
+		return nil, err
+	}
+	a, _ := obj.(Array)
+	return a, nil
+}
+
+// compressedObject reads an object stored in an object stream.
+func (r *Reader) compressedObject(entry xrefEntry) (Object, error) {
+	stm, ok := r.objStms[entry.stream]
+	if !ok {
+		obj, err := r.Object(Ref{Num: entry.stream})
+		if err != nil {
+			return nil, err
+		}
+		s, ok := obj.(*Stream)
+		if !ok {
+			return nil, fmt.Errorf("pdf: object stream %d is not a stream", entry.stream)
+		}
+		data, err := DecodeStream(s)
+		if err != nil {
+			return nil, fmt.Errorf("pdf: object stream %d: %w", entry.stream, err)
+		}
+		n, _ := s.Dict["N"].(int)
+		first, _ := s.Dict["First"].(int)
+		first = min(first, len(data))
+		stm = &objStm{data: data[first:]}
+		p := &parser{data: data[:first]}
+		for i := 0; i < n; i++ {
+			_, err1 := p.parseObject() // object number
+			off, err2 := p.parseObject()
+			offset, ok := off.(int)
+			if err1 != nil || err2 != nil || !ok {
+				return nil, fmt.Errorf("pdf: object stream %d: bad header", entry.stream)
+			}
+			stm.offsets = append(stm.offsets, offset)
+		}
+		r.objStms[entry.stream] = stm
+	}
+	if entry.index < 0 || entry.index >= len(stm.offsets) {
+		return nil, fmt.Errorf("pdf: object stream %d has no object at index %d", entry.stream, entry.index)
+	}
+	p := &parser{data: stm.data, pos: stm.offsets[entry.index], r: r}
+	return p.parseObject()
+}
+
+// findStartXRef returns the offset given after the last startxref keyword.
+func findStartXRef(data []byte) (int, error) {
+	tail := data[max(0, len(data)-2048):]
+	i := bytes.LastIndex(tail, []byte("startxref"))
+	if i < 0 {
+		return 0, errors.New("pdf: startxref not found")
+	}
+	p := &parser{data: tail, pos: i + len("startxref")}
+	obj, err := p.parseObject()
+	offset, ok := obj.(int)
+	if err != nil || !ok || offset < 0 || offset >= len(data) {
+		return 0, errors.New("pdf: invalid startxref offset")
+	}
+	return offset, nil
+}
+
+// readXRef reads the cross-reference table or stream at offset and returns
+// its trailer. Entries already known from a later section are kept.
+func (r *Reader) readXRef(offset int) (Dict, error) {
+	p := &parser{data: r.data, pos: offset, r: r}
+	if p.keyword("xref") {
+		return r.readXRefTable(p)
+	}
+
+	p.token() // object number
+	p.token() // generation
+	if !p.keyword("obj") {
+		return nil, fmt.Errorf("pdf: no cross-reference section at offset %d", offset)
+	}
+	obj, err := p.parseObject()
+	if err != nil {
+		return nil, fmt.Errorf("pdf: cross-reference stream at %d: %w", offset, err)
+	}
+	dict, ok := obj.(Dict)
+	if !ok || !p.keyword("stream") {
+		return nil, fmt.Errorf("pdf: no cross-reference section at offset %d", offset)
+	}
+	s, err := p.parseStream(dict)
+	if err != nil {
+		return nil, err
+	}
+	return dict, r.readXRefStream(s)
+}
+
+func (r *Reader) readXRefTable(p *parser) (Dict, error) {
+	for {
+		if p.keyword("trailer") {
+			obj, err := p.parseObject()
+			if err != nil {
+				return nil, fmt.Errorf("pdf: trailer: %w", err)
+			}
+			trailer, ok := obj.(Dict)
+			if !ok {
+				return nil, errors.New("pdf: trailer is not a dictionary")
+			}
+			return trailer, nil
+		}
+		first, err1 := p.parseObject()
+		count, err2 := p.parseObject()
+		start, ok1 := first.(int)
+		n, ok2 := count.(int)
+		if err1 != nil || err2 != nil || !ok1 || !ok2 {
+			return nil, errors.New("pdf: malformed cross-reference table")
+		}
+		for i := 0; i < n; i++ {
+			off, err1 := p.parseObject()
+			_, err2 := p.parseObject()
+			p.skipSpace()
+			if err1 != nil || err2 != nil || p.pos >= len(p.data) {
+				return nil, errors.New("pdf: malformed cross-reference entry")
+			}
+			kind := p.data[p.pos]
+			p.pos++
+			offset, _ := off.(int)
+			if _, known := r.xref[start+i]; !known && kind == 'n' {
+				r.xref[start+i] = xrefEntry{offset: offset}
+			} else if !known && kind == 'f' {
+				r.xref[start+i] = xrefEntry{offset: -1}
+			}
+		}
+	}
+}
+
+func (r *Reader) readXRefStream(s *Stream) error {
+	data, err := DecodeStream(s)
+	if err != nil {
+		return fmt.Errorf("pdf: cross-reference stream: %w", err)
+	}
+	w, _ := s.Dict["W"].(Array)
+	if len(w) != 3 {
+		return errors.New("pdf: cross-reference stream has no valid W entry")
+	}
+	widths := make([]int, 3)
+	rowLen := 0
+	for i, v := range w {
+		widths[i], _ = v.(int)
+		rowLen += widths[i]
+	}
+	index, _ := s.Dict["Index"].(Array)
+	if index == nil {
+		size, _ := s.Dict["Size"].(int)
+		index = Array{0, size}
+	}
+	for i := 0; i+1 < len(index); i += 2 {
+		start, _ := index[i].(int)
+		count, _ := index[i+1].(int)
+		for j := 0; j < count; j++ {
+			if len(data) < rowLen {
+				return errors.New("pdf: cross-reference stream is truncated")
+			}
+			row := data[:rowLen]
+			data = data[rowLen:]
+			fields := make([]int, 3)
+			pos := 0
+			for k, width := range widths {
+				for b := 0; b < width; b++ {
+					fields[k] = fields[k]<<8 | int(row[pos])
+					pos++
+				}
+			}
+			if widths[0] == 0 {
+				fields[0] = 1
+			}
+			num := start + j
+			if _, known := r.xref[num]; known {
+				continue
+			}
+			switch fields[0] {
+			case 0:
+				r.xref[num] = xrefEntry{offset: -1}
+			case 1:
+				r.xref[num] = xrefEntry{offset: fields[1]}
+			case 2:
+				r.xref[num] = xrefEntry{stream: fields[1], index: fields[2]}
+			}
+		}
+	}
+	return nil
+}
+
+// DecodeStream returns the decoded data of a stream. It supports no filter
+// and FlateDecode with PNG predictors.
+func DecodeStream(s *Stream) ([]byte, error) {
+	filter := s.Dict["Filter"]
+	if a, ok := filter.(Array); ok {
+		if len(a) > 1 {
+			return nil, fmt.Errorf("unsupported filter chain %v", a)
+		}
+		if len(a) == 1 {
+			filter = a[0]
+		} else {
+			filter = nil
+		}
+	}
+	switch filter {
+	case nil:
+		return s.Data, nil
+	case Name("FlateDecode"):
+	default:
+		return nil, fmt.Errorf("unsupported filter %v", filter)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(s.Data))
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	parms, _ := s.Dict["DecodeParms"].(Dict)
+	predictor, _ := parms["Predictor"].(int)
+	if predictor < 10 {
+		return data, nil
+	}
+	columns, ok := parms["Columns"].(int)
+	if !ok {
+		columns = 1
+	}
+	return unpredictPNG(data, columns)
+}
+
+// unpredictPNG reverses the PNG row filters of predictor 10 to 15 data with
+// one byte per pixel.
+func unpredictPNG(data []byte, columns int) ([]byte, error) {
+	rowLen := columns + 1
+	if len(data)%rowLen != 0 {
+		return nil, errors.New("predicted data is not a whole number of rows")
+	}
+	out := make([]byte, 0, len(data)/rowLen*columns)
+	prev := make([]byte, columns)
+	for i := 0; i < len(data); i += rowLen {
+		filter, row := data[i], append([]byte(nil), data[i+1:i+rowLen]...)
+		for j := range row {
+			var left, upLeft byte
+			if j > 0 {
+				left, upLeft = row[j-1], prev[j-1]
+			}
+			up := prev[j]
+			switch filter {
+			case 0:
+			case 1:
+				row[j] += left
+			case 2:
+				row[j] += up
+			case 3:
+				row[j] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[j] += paeth(left, up, upLeft)
+			default:
+				return nil, fmt.Errorf("unknown PNG filter %d", filter)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// parser reads objects from PDF syntax. Stream lengths given by indirect
+// references are resolved through r.
+type parser struct {
+	data []byte
+	pos  int
+	r    *Reader
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+// skipSpace skips white space and comments.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case isSpace(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token reads a regular token such as a number or keyword.
+func (p *parser) token() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.data) && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// keyword consumes the keyword if it comes next.
+func (p *parser) keyword(kw string) bool {
+	save := p.pos
+	if p.token() == kw {
+		return true
+	}
+	p.pos = save
+	return false
+}
+
+// parseObject reads one object. Integers followed by a generation and R are
+// returned as references.
+func (p *parser) parseObject() (Object, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	switch c := p.data[p.pos]; c {
+	case '/':
+		p.pos++
+		return Name(p.parseName()), nil
+	case '(':
+		p.pos++
+		return p.parseLiteral()
+	case '<':
+		if p.pos+1 < len(p.data) && p.data[p.pos+1] == '<' {
+			p.pos += 2
+			return p.parseDict()
+		}
+		p.pos++
+		return p.parseHex()
+	case '[':
+		p.pos++
+		var a Array
+		for {
+			p.skipSpace()
+			if p.pos >= len(p.data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			if p.data[p.pos] == ']' {
+				p.pos++
+				return a, nil
+			}
+			obj, err := p.parseObject()
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, obj)
+		}
+	}
+
+	tok := p.token()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.data[p.pos], p.pos)
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	n, err := strconv.Atoi(tok)
+	if err != nil {
+		f, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected token %q at offset %d", tok, p.pos-len(tok))
+		}
+		return f, nil
+	}
+	// A reference is "num gen R".
+	save := p.pos
+	if gen, err := strconv.Atoi(p.token()); err == nil && p.token() == "R" {
+		return Ref{Num: n, Gen: gen}, nil
+	}
+	p.pos = save
+	return n, nil
+}
+
+func (p *parser) parseName() string {
+	var b []byte
+	for p.pos < len(p.data) && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		c := p.data[p.pos]
+		if c == '#' && p.pos+2 < len(p.data) {
+			if v, err := strconv.ParseUint(string(p.data[p.pos+1:p.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				p.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		p.pos++
+	}
+	return string(b)
+}
+
+func (p *parser) parseLiteral() (Object, error) {
+	var b []byte
+	depth := 1
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return String(b), nil
+			}
+		case '\\':
+			if p.pos >= len(p.data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for k := 0; k < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; k++ {
+						v = v*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (p *parser) parseHex() (Object, error) {
+	var digits []byte
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		if c == '>' {
+			if len(digits)%2 == 1 {
+				digits = append(digits, '0')
+			}
+			out := make([]byte, len(digits)/2)
+			for i := range out {
+				v, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+				if err != nil {
+					return nil, fmt.Errorf("invalid hex string at offset %d", p.pos)
+				}
+				out[i] = byte(v)
+			}
+			return HexString(out), nil
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (p *parser) parseDict() (Object, error) {
+	d := Dict{}
+	for {
+		p.skipSpace()
+		if p.pos+1 < len(p.data) && p.data[p.pos] == '>' && p.data[p.pos+1] == '>' {
+			p.pos += 2
+			return d, nil
+		}
+		key, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(Name)
+		if !ok {
+			return nil, fmt.Errorf("dictionary key is not a name at offset %d", p.pos)
+		}
+		value, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		d[name] = value
+	}
+}
+
+// parseStream reads the stream data following the stream keyword.
+func (p *parser) parseStream(dict Dict) (*Stream, error) {
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+		p.pos++
+	}
+	length := dict["Length"]
+	if ref, ok := length.(Ref); ok && p.r != nil {
+		var err error
+		if length, err = p.r.Object(ref); err != nil {
+			return nil, err
+		}
+	}
+	n, ok := length.(int)
+	if !ok || n < 0 || p.pos+n > len(p.data) {
+		// Fall back to searching for the end of the stream.
+		end := bytes.Index(p.data[p.pos:], []byte("endstream"))
+		if end < 0 {
+			return nil, errors.New("stream has no endstream")
+		}
+		n = end
+		for n > 0 && (p.data[p.pos+n-1] == '\n' || p.data[p.pos+n-1] == '\r') {
+			n--
+		}
+	}
+	s := &Stream{Dict: dict, Data: p.data[p.pos : p.pos+n]}
+	p.pos += n
+	return s, nil
+}
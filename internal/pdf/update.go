@@ -0,0 +1,88 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// Update collects new and changed objects and appends them to an existing
+// file as an incremental update, leaving the original bytes untouched.
+type Update struct {
+	r       *Reader
+	objects map[int]Object
+	size    int
+}
+
+// NewUpdate starts an incremental update of the file read by r.
+func (r *Reader) NewUpdate() *Update {
+	return &Update{r: r, objects: make(map[int]Object), size: r.Size()}
+}
+
+// Alloc reserves a new object number.
+func (u *Update) Alloc() Ref {
+	ref := Ref{Num: u.size}
+	u.size++
+	u.objects[ref.Num] = nil
+	return ref
+}
+
+// Set stores the object for ref, replacing an existing object of the file
+// when ref refers to one.
+func (u *Update) Set(ref Ref, obj Object) {
+	u.objects[ref.Num] = obj
+}
+
+// Add stores a new object and returns its reference.
+func (u *Update) Add(obj Object) Ref {
+	ref := u.Alloc()
+	u.Set(ref, obj)
+	return ref
+}
+
+// Bytes returns the original file followed by the changed objects, a
+// cross-reference table for them and a trailer. The Size and Prev entries
+// of the trailer are filled in automatically.
+func (u *Update) Bytes(trailer Dict) []byte {
+	var buf bytes.Buffer
+	buf.Write(u.r.data)
+	if n := len(u.r.data); n > 0 && u.r.data[n-1] != '\n' && u.r.data[n-1] != '\r' {
+		buf.WriteByte('\n')
+	}
+
+	nums := make([]int, 0, len(u.objects))
+	for num := range u.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	offsets := make(map[int]int, len(nums))
+	for _, num := range nums {
+		offsets[num] = buf.Len()
+		(&encoder{buf: &buf}).writeIndirect(num, u.objects[num])
+	}
+
+	xref := buf.Len()
+	buf.WriteString("xref\n")
+	for i := 0; i < len(nums); {
+		j := i + 1
+		for j < len(nums) && nums[j] == nums[j-1]+1 {
+			j++
+		}
+		fmt.Fprintf(&buf, "%d %d\n", nums[i], j-i)
+		for _, num := range nums[i:j] {
+			fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[num])
+		}
+		i = j
+	}
+
+	t := make(Dict, len(trailer)+2)
+	for k, v := range trailer {
+		t[k] = v
+	}
+	t["Size"] = u.size
+	t["Prev"] = u.r.startXRef
+	buf.WriteString("trailer\n")
+	(&encoder{buf: &buf}).write(t)
+	fmt.Fprintf(&buf, "\nstartxref\n%d\n%%%%EOF\n", xref)
+	return buf.Bytes()
+}
@@ -0,0 +1,107 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+)
+
+// Writer collects indirect objects and serializes them into a complete PDF file.
+type Writer struct {
+	objects []Object
+	crypt   Encrypter
+	exempt  map[int]bool
+}
+
+// NewWriter creates an empty Writer.
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// Alloc reserves an object number so it can be referenced before it is set.
+func (w *Writer) Alloc() Ref {
+	w.objects = append(w.objects, nil)
+	return Ref{Num: len(w.objects)}
+}
+
+// Set stores the object for a previously allocated reference.
+func (w *Writer) Set(ref Ref, obj Object) {
+	w.objects[ref.Num-1] = obj
+}
+
+// Add stores a new indirect object and returns its reference.
+func (w *Writer) Add(obj Object) Ref {
+	ref := w.Alloc()
+	w.Set(ref, obj)
+	return ref
+}
+
+// SetEncrypter encrypts the strings and streams of every object when the
+// file is written, except the exempt ones such as the encryption dictionary.
+func (w *Writer) SetEncrypter(crypt Encrypter, exempt ...Ref) {
+	w.crypt = crypt
+	w.exempt = make(map[int]bool, len(exempt))
+	for _, ref := range exempt {
+		w.exempt[ref.Num] = true
+	}
+}
+
+// Bytes serializes all objects followed by the cross-reference table and
+// trailer. The Size entry of the trailer is filled in automatically.
+func (w *Writer) Bytes(trailer Dict) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+
+	offsets := make([]int, len(w.objects))
+	for i, obj := range w.objects {
+		offsets[i] = buf.Len()
+		enc := &encoder{buf: &buf}
+		if w.crypt != nil && !w.exempt[i+1] {
+			ref := Ref{Num: i + 1}
+			enc.crypt = func(data []byte) []byte { return w.crypt.Encrypt(ref, data) }
+		}
+		enc.writeIndirect(i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(w.objects)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+
+	t := make(Dict, len(trailer)+1)
+	for k, v := range trailer {
+		t[k] = v
+	}
+	t["Size"] = len(w.objects) + 1
+	buf.WriteString("trailer\n")
+	(&encoder{buf: &buf}).write(t)
+	fmt.Fprintf(&buf, "\nstartxref\n%d\n%%%%EOF\n", xref)
+	return buf.Bytes()
+}
+
+// writeIndirect writes obj as the indirect object num.
+func (e *encoder) writeIndirect(num int, obj Object) {
+	fmt.Fprintf(e.buf, "%d 0 obj\n", num)
+	e.write(obj)
+	e.buf.WriteString("\nendobj\n")
+}
+
+// Compress deflates data for use with the FlateDecode filter.
+func Compress(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// FlateStream returns a stream whose data is compressed with FlateDecode.
+func FlateStream(dict Dict, data []byte) *Stream {
+	if dict == nil {
+		dict = Dict{}
+	}
+	dict["Filter"] = Name("FlateDecode")
+	return &Stream{Dict: dict, Data: Compress(data)}
+}
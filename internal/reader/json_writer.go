@@ -0,0 +1,53 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// MarshalDocument encodes a document as indented JSON that the JSON readers
+// read back unchanged.
+func MarshalDocument(doc *domain.Document) ([]byte, error) {
+	if doc == nil {
+		return nil, domain.ErrDocumentNil
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidJSON, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// JSONFileWriter writes documents to JSON files.
+type JSONFileWriter struct {
+	filePath string
+}
+
+// NewJSONFileWriter creates a new JSONFileWriter.
+func NewJSONFileWriter(filePath string) *JSONFileWriter {
+	return &JSONFileWriter{
+		filePath: filePath,
+	}
+}
+
+// Write encodes the document and writes it to the file.
+func (w *JSONFileWriter) Write(ctx context.Context, doc *domain.Document) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	data, err := MarshalDocument(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(w.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
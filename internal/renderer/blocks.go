@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// renderBlocks lays out blocks from top to bottom. keepNext is the height
+// that must follow the last block on the same page.
+func (l *layout) renderBlocks(blocks []domain.Block, keepNext float64) {
+	for i, b := range blocks {
+		keep := l.keepHeight(blocks, i+1)
+		if i == len(blocks)-1 {
+			keep = keepNext
+		}
+		l.renderBlock(b, keep)
+	}
+}
+
+// renderBlock lays out a single block.
+func (l *layout) renderBlock(b domain.Block, keepNext float64) {
+	switch b := b.(type) {
+	case domain.Table:
+		l.renderTable(b, keepNext)
+	case domain.PageBreak:
+		if l.y > l.top() {
+			l.newPage()
+		}
+	case domain.Spacer:
+		l.y = min(l.y+b.Height.Points(), l.bottom())
+	case domain.Group:
+		l.ensureSpace(l.blocksHeight(b.Blocks) + keepNext)
+		l.renderBlocks(b.Blocks, keepNext)
+	case domain.Paragraph:
+		l.renderParagraph(b, keepNext)
+	case domain.Heading:
+		l.renderHeading(b, keepNext)
+	case domain.List:
+		l.renderList(b, keepNext)
+	case domain.TableOfContents:
+		l.renderContents(b, keepNext)
+	}
+}
+
+// blocksHeight returns the height blocks take when laid out on one page.
+func (l *layout) blocksHeight(blocks []domain.Block) float64 {
+	total := 0.0
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case domain.Table:
+			_, g := l.measureTable(b)
+			total += sum(g.heights)
+		case domain.Spacer:
+			total += b.Height.Points()
+		case domain.Group:
+			total += l.blocksHeight(b.Blocks)
+		case domain.Paragraph:
+			total += l.paragraphHeight(b)
+		case domain.Heading:
+			total += l.headingHeight(b)
+		case domain.List:
+			total += l.listHeight(b)
+		case domain.TableOfContents:
+			total += l.contentsHeight(b)
+		}
+	}
+	return total
+}
+
+// keepHeight returns the height of blocks[i] that must stay on the page of
+// the block before it: the lead of a block following a heading or of a
+// table kept with the content before it, and zero otherwise.
+func (l *layout) keepHeight(blocks []domain.Block, i int) float64 {
+	if i >= len(blocks) {
+		return 0
+	}
+	if i > 0 {
+		if _, ok := blocks[i-1].(domain.Heading); ok {
+			return l.blockLeadHeight(blocks[i])
+		}
+	}
+	t, ok := blocks[i].(domain.Table)
+	if !ok || !t.KeepWithPrevious {
+		return 0
+	}
+	return l.leadHeight(t)
+}
+
+// blockLeadHeight returns the least height of a block that may start a
+// page.
+func (l *layout) blockLeadHeight(b domain.Block) float64 {
+	switch b := b.(type) {
+	case domain.Table:
+		return l.leadHeight(b)
+	case domain.Group:
+		return l.blocksHeight(b.Blocks)
+	case domain.Paragraph:
+		return paragraphStyle(b).leading()
+	case domain.Heading:
+		st := headingStyle(b)
+		return float64(len(textLines(b.Text, st, l.contentWidth()))) * st.leading()
+	case domain.List:
+		return listStyle(b, parseStyle("")).leading()
+	case domain.TableOfContents:
+		titleStyle, st := contentsStyles(b)
+		if b.Title != "" {
+			return float64(len(textLines(b.Title, titleStyle, l.contentWidth()))) * titleStyle.leading()
+		}
+		return st.leading()
+	}
+	return 0
+}
@@ -0,0 +1,161 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// canvas accumulates content stream operators. Coordinates passed to its
+// methods are in PDF user space (origin at the bottom-left corner).
+type canvas struct {
+	buf bytes.Buffer
+}
+
+// op writes one line of operands and operators separated by spaces.
+// Numbers are formatted compactly; strings are written verbatim.
+func (c *canvas) op(tokens ...interface{}) {
+	for i, t := range tokens {
+		if i > 0 {
+			c.buf.WriteByte(' ')
+		}
+		switch v := t.(type) {
+		case float64:
+			c.buf.WriteString(pdf.FormatNumber(v))
+		case int:
+			c.buf.WriteString(strconv.Itoa(v))
+		case string:
+			c.buf.WriteString(v)
+		default:
+			panic(fmt.Sprintf("renderer: unsupported content token %T", t))
+		}
+	}
+	c.buf.WriteByte('\n')
+}
+
+// line strokes a straight line of the given width.
+func (c *canvas) line(x1, y1, x2, y2, width float64) {
+	c.op(width, "w", x1, y1, "m", x2, y2, "l", "S")
+}
+
+// text draws encoded text with its baseline starting at (x, y).
+func (c *canvas) text(x, y float64, face fontFace, size float64, encoded []byte) {
+	c.op("BT", "/"+face.resourceName(), size, "Tf", x, y, "Td", pdf.Literal(encoded), "Tj", "ET")
+}
+
+// justifiedText draws text like text, widening every space by wordSpacing
+// points. The word spacing is reset afterwards since it outlives the text
+// object.
+func (c *canvas) justifiedText(x, y float64, face fontFace, size, wordSpacing float64, encoded []byte) {
+	c.op("BT", "/"+face.resourceName(), size, "Tf", wordSpacing, "Tw", x, y, "Td",
+		pdf.Literal(encoded), "Tj", 0, "Tw", "ET")
+}
+
+// rotatedText draws text starting at (x, y) rotated counter-clockwise by angle degrees.
+func (c *canvas) rotatedText(x, y, angle float64, face fontFace, size float64, encoded []byte) {
+	rad := angle * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	c.op("BT", "/"+face.resourceName(), size, "Tf", cos, sin, -sin, cos, x, y, "Tm",
+		pdf.Literal(encoded), "Tj", "ET")
+}
+
+// gray sets the fill color to the given gray level (0 black, 1 white).
+func (c *canvas) gray(level float64) {
+	c.op(level, "g")
+}
+
+// fillColor sets the non-stroking color.
+func (c *canvas) fillColor(col rgb) {
+	c.op(col[0], col[1], col[2], "rg")
+}
+
+// strokeColor sets the stroking color.
+func (c *canvas) strokeColor(col rgb) {
+	c.op(col[0], col[1], col[2], "RG")
+}
+
+// fillRect fills a rectangle whose lower-left corner is at (x, y).
+func (c *canvas) fillRect(x, y, w, h float64, col rgb) {
+	c.save()
+	c.fillColor(col)
+	c.op(x, y, w, h, "re", "f")
+	c.restore()
+}
+
+// clip intersects the clipping path with a rectangle whose lower-left corner
+// is at (x, y).
+func (c *canvas) clip(x, y, w, h float64) {
+	c.op(x, y, w, h, "re", "W", "n")
+}
+
+// save pushes the graphics state.
+func (c *canvas) save() {
+	c.op("q")
+}
+
+// restore pops the graphics state.
+func (c *canvas) restore() {
+	c.op("Q")
+}
+
+// circle appends a circle path using four Bézier curves.
+func (c *canvas) circle(cx, cy, r float64) {
+	c.ellipse(cx, cy, r, r)
+}
+
+// ellipse appends an ellipse path with radii rx and ry using four Bézier
+// curves.
+func (c *canvas) ellipse(cx, cy, rx, ry float64) {
+	kx, ky := 0.5523*rx, 0.5523*ry
+	c.op(cx+rx, cy, "m")
+	c.op(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry, "c")
+	c.op(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy, "c")
+	c.op(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry, "c")
+	c.op(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy, "c")
+}
+
+// roundRect appends a rectangle path whose lower-left corner is at (x, y),
+// with corners rounded by r. The radius is limited to half the shorter side.
+func (c *canvas) roundRect(x, y, w, h, r float64) {
+	r = min(r, w/2, h/2)
+	if r <= 0 {
+		c.op(x, y, w, h, "re")
+		return
+	}
+	k := 0.5523 * r
+	c.op(x+r, y, "m")
+	c.op(x+w-r, y, "l")
+	c.op(x+w-r+k, y, x+w, y+r-k, x+w, y+r, "c")
+	c.op(x+w, y+h-r, "l")
+	c.op(x+w, y+h-r+k, x+w-r+k, y+h, x+w-r, y+h, "c")
+	c.op(x+r, y+h, "l")
+	c.op(x+r-k, y+h, x, y+h-r+k, x, y+h-r, "c")
+	c.op(x, y+r, "l")
+	c.op(x, y+r-k, x+r-k, y, x+r, y, "c")
+	c.op("h")
+}
+
+// image paints a named image XObject into the rectangle whose lower-left
+// corner is at (x, y).
+func (c *canvas) image(name string, x, y, w, h float64) {
+	c.op("q", w, 0, 0, h, x, y, "cm", "/"+name, "Do", "Q")
+}
+
+// prepend inserts the operators of another canvas before the ones already
+// written, so that they are painted beneath them.
+func (c *canvas) prepend(under *canvas) {
+	if under.buf.Len() == 0 {
+		return
+	}
+	data := append(bytes.Clone(under.buf.Bytes()), c.buf.Bytes()...)
+	c.buf.Reset()
+	c.buf.Write(data)
+}
+
+// bytes returns the accumulated content stream.
+func (c *canvas) bytes() []byte {
+	return c.buf.Bytes()
+}
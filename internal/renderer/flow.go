@@ -0,0 +1,229 @@
+package renderer
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Flowing text layout, in points or as fractions of the font size.
+const (
+	listIndent       = 18
+	markerGap        = 4
+	paragraphSpacing = 0.6 // after a paragraph or list
+	headingBefore    = 0.8
+	headingAfter     = 0.4
+)
+
+// headingSizes are the default font sizes of heading levels 1 to 4.
+var headingSizes = [domain.MaxHeadingLevel]float64{18, 14, 12, 10}
+
+// listBullets are the bullets of unordered lists, by nesting level.
+var listBullets = [...]string{"•", "–"}
+
+// textLine is a wrapped line of text. Justified lines are stretched to the
+// full width; the last line of a paragraph never is.
+type textLine struct {
+	text    string
+	justify bool
+}
+
+// textLines wraps text to the given width for the style.
+func textLines(text string, st cellStyle, width float64) []textLine {
+	var lines []textLine
+	for _, para := range wrapParagraphs(text, st.face(), st.size, width) {
+		for i, line := range para {
+			justify := st.alignment == domain.AlignJustify && i < len(para)-1
+			lines = append(lines, textLine{text: line, justify: justify})
+		}
+	}
+	return lines
+}
+
+// drawLine draws a line of text whose top is at y, aligned in a box of
+// width w starting at x.
+func (l *layout) drawLine(x, y, w float64, st cellStyle, line textLine) {
+	c := &l.cur.canvas
+	if st.textColor != nil {
+		c.save()
+		defer c.restore()
+		c.fillColor(*st.textColor)
+		c.strokeColor(*st.textColor)
+	}
+	encoded := encodeWinAnsi(line.text)
+	lw := textWidth(encoded, st.face(), st.size)
+	lx := alignX(x, w, lw, st.alignment)
+	baseline := l.pdfY(y + st.size*0.86)
+	if spaces := bytes.Count(encoded, []byte{' '}); line.justify && spaces > 0 && lw < w {
+		c.justifiedText(lx, baseline, st.face(), st.size, (w-lw)/float64(spaces), encoded)
+		lw = w
+	} else {
+		c.text(lx, baseline, st.face(), st.size, encoded)
+	}
+	if st.underline {
+		c.line(lx, baseline-st.size*0.1, lx+lw, baseline-st.size*0.1, st.size*0.05)
+	}
+}
+
+// flowLines draws lines one below the other at x, breaking pages between
+// them. keepNext is the height that must follow the last line on its page.
+// A link covers every line across the full width.
+func (l *layout) flowLines(x, w float64, st cellStyle, lines []textLine, keepNext float64, link string) {
+	for i, line := range lines {
+		need := st.leading()
+		if i == len(lines)-1 {
+			need += keepNext
+		}
+		l.ensureSpace(need)
+		l.drawLine(x, l.y, w, st, line)
+		if link != "" {
+			l.addLink(x, l.pdfY(l.y+st.leading()), x+w, l.pdfY(l.y), link)
+		}
+		l.y += st.leading()
+	}
+}
+
+// paragraphStyle resolves the style of a paragraph. Borders do not apply.
+func paragraphStyle(p domain.Paragraph) cellStyle {
+	st := parseStyle(p.Props)
+	st.borders = [4]float64{}
+	st.textColor = colorOf(p.TextColor)
+	return st
+}
+
+// headingStyle resolves the style of a heading: its props when set, bold
+// text sized by level otherwise.
+func headingStyle(h domain.Heading) cellStyle {
+	st := cellStyle{bold: true, alignment: domain.AlignLeft}
+	if h.Props != "" {
+		st = parseStyle(h.Props)
+		st.borders = [4]float64{}
+	} else {
+		level := min(max(h.Level, domain.MinHeadingLevel), domain.MaxHeadingLevel)
+		st.size = headingSizes[level-1]
+	}
+	st.textColor = colorOf(h.TextColor)
+	return st
+}
+
+// listStyle resolves the style of a list, falling back to that of the
+// enclosing list when the props are empty.
+func listStyle(list domain.List, parent cellStyle) cellStyle {
+	if list.Props == "" {
+		return parent
+	}
+	st := parseStyle(list.Props)
+	st.borders = [4]float64{}
+	return st
+}
+
+// renderParagraph flows a paragraph over as many pages as it needs.
+func (l *layout) renderParagraph(p domain.Paragraph, keepNext float64) {
+	st := paragraphStyle(p)
+	l.flowLines(l.left(), l.contentWidth(), st, textLines(p.Text, st, l.contentWidth()), keepNext, p.Link)
+	l.y = min(l.y+st.size*paragraphSpacing, l.bottom())
+}
+
+// renderHeading draws a heading on the same page as keepNext points of the
+// content after it. The space above a heading is dropped at the top of a
+// page. Its position is recorded for tables of contents.
+func (l *layout) renderHeading(h domain.Heading, keepNext float64) {
+	l.headings = append(l.headings, l.drawHeading(headingStyle(h), h.Text, keepNext))
+}
+
+// drawHeading draws heading text and returns the destination of its top.
+func (l *layout) drawHeading(st cellStyle, text string, keepNext float64) pdf.Array {
+	lines := textLines(text, st, l.contentWidth())
+	before, after := st.size*headingBefore, st.size*headingAfter
+	l.ensureSpace(before + float64(len(lines))*st.leading() + after + keepNext)
+	if l.y > l.top() {
+		l.y += before
+	}
+	dest := l.dest()
+	l.flowLines(l.left(), l.contentWidth(), st, lines, 0, "")
+	l.y += after
+	return dest
+}
+
+// renderList draws a list and its nested lists.
+func (l *layout) renderList(list domain.List, keepNext float64) {
+	st := listStyle(list, parseStyle(""))
+	l.drawList(list, st, 0, keepNext)
+	l.y = min(l.y+st.size*paragraphSpacing, l.bottom())
+}
+
+// drawList draws the items of a list indented by its nesting level, with
+// the bullet or number right-aligned in the indent before the first line.
+func (l *layout) drawList(list domain.List, st cellStyle, level int, keepNext float64) {
+	x := l.left() + float64(level+1)*listIndent
+	w := l.contentWidth() - float64(level+1)*listIndent
+	marker := st
+	marker.alignment = domain.AlignRight
+	marker.underline = false
+	for i, item := range list.Items {
+		keep := 0.0
+		if i == len(list.Items)-1 {
+			keep = keepNext
+		}
+		lines := textLines(item.Text, st, w)
+		for j, line := range lines {
+			need := st.leading()
+			if j == len(lines)-1 && item.List == nil {
+				need += keep
+			}
+			l.ensureSpace(need)
+			if j == 0 {
+				l.drawLine(x-listIndent, l.y, listIndent-markerGap, marker, textLine{text: listMarker(list, level, i)})
+			}
+			l.drawLine(x, l.y, w, st, line)
+			l.y += st.leading()
+		}
+		if item.List != nil {
+			l.drawList(*item.List, listStyle(*item.List, st), level+1, keep)
+		}
+	}
+}
+
+// listMarker returns the bullet or number of the i-th item of a list.
+func listMarker(list domain.List, level, i int) string {
+	if list.Ordered {
+		return strconv.Itoa(i+1) + "."
+	}
+	return listBullets[level%len(listBullets)]
+}
+
+// paragraphHeight returns the height of a paragraph including the space
+// after it.
+func (l *layout) paragraphHeight(p domain.Paragraph) float64 {
+	st := paragraphStyle(p)
+	lines := textLines(p.Text, st, l.contentWidth())
+	return float64(len(lines))*st.leading() + st.size*paragraphSpacing
+}
+
+// headingHeight returns the height of a heading including the space around
+// it.
+func (l *layout) headingHeight(h domain.Heading) float64 {
+	st := headingStyle(h)
+	lines := textLines(h.Text, st, l.contentWidth())
+	return float64(len(lines))*st.leading() + st.size*(headingBefore+headingAfter)
+}
+
+// listHeight returns the height of a list including the space after it.
+func (l *layout) listHeight(list domain.List) float64 {
+	st := listStyle(list, parseStyle(""))
+	return l.itemsHeight(list, st, 0) + st.size*paragraphSpacing
+}
+
+func (l *layout) itemsHeight(list domain.List, st cellStyle, level int) float64 {
+	w := l.contentWidth() - float64(level+1)*listIndent
+	total := 0.0
+	for _, item := range list.Items {
+		total += float64(len(textLines(item.Text, st, w))) * st.leading()
+		if item.List != nil {
+			total += l.itemsHeight(*item.List, listStyle(*item.List, st), level+1)
+		}
+	}
+	return total
+}
@@ -0,0 +1,149 @@
+package renderer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// fontFace identifies one of the standard Helvetica faces used by the renderer.
+type fontFace int
+
+const (
+	faceRegular fontFace = iota
+	faceBold
+	faceItalic
+	faceBoldItalic
+)
+
+// resourceName returns the font resource name used in content streams.
+func (f fontFace) resourceName() string {
+	return [...]string{"F1", "F2", "F3", "F4"}[f]
+}
+
+// baseFont returns the PDF standard 14 font name for the face.
+func (f fontFace) baseFont() string {
+	return [...]string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"}[f]
+}
+
+// faceFor picks the face matching the bold and italic flags.
+func faceFor(bold, italic bool) fontFace {
+	switch {
+	case bold && italic:
+		return faceBoldItalic
+	case bold:
+		return faceBold
+	case italic:
+		return faceItalic
+	default:
+		return faceRegular
+	}
+}
+
+// Glyph widths for characters 32-126 in 1/1000 em, from the Adobe AFM files.
+// The oblique faces share the metrics of their upright counterparts.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// defaultGlyphWidth is used for characters outside the printable ASCII range.
+const defaultGlyphWidth = 556
+
+// textWidth measures encoded text in points for the given face and size.
+func textWidth(encoded []byte, face fontFace, size float64) float64 {
+	widths := &helveticaWidths
+	if face == faceBold || face == faceBoldItalic {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range encoded {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += defaultGlyphWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsiSpecials maps the non-Latin-1 characters of WinAnsiEncoding to their byte codes.
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encodeWinAnsi converts UTF-8 text to WinAnsiEncoding, replacing
+// characters that cannot be represented with '?'.
+func encodeWinAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiSpecials[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+// wrapText breaks text into lines no wider than maxWidth. Explicit newlines
+// always start a new line; words longer than a line are kept whole.
+func wrapText(s string, face fontFace, size, maxWidth float64) []string {
+	var lines []string
+	for _, para := range wrapParagraphs(s, face, size, maxWidth) {
+		lines = append(lines, para...)
+	}
+	return lines
+}
+
+// wrapParagraphs is wrapText keeping the lines of each newline-separated
+// paragraph apart, so that the last line of a paragraph can be told from
+// the others.
+func wrapParagraphs(s string, face fontFace, size, maxWidth float64) [][]string {
+	var paras [][]string
+	for _, para := range strings.Split(s, "\n") {
+		words := strings.Fields(para)
+		if len(words) == 0 {
+			paras = append(paras, []string{""})
+			continue
+		}
+		var lines []string
+		line := words[0]
+		for _, word := range words[1:] {
+			candidate := line + " " + word
+			if textWidth(encodeWinAnsi(candidate), face, size) > maxWidth {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line = candidate
+		}
+		paras = append(paras, append(lines, line))
+	}
+	return paras
+}
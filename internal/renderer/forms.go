@@ -0,0 +1,481 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Field flags from the PDF specification.
+const (
+	flagReadOnly      = 1 << 0
+	flagRequired      = 1 << 1
+	flagMultiline     = 1 << 12
+	flagNoToggleToOff = 1 << 14
+	flagRadio         = 1 << 15
+	flagCombo         = 1 << 17
+	flagEdit          = 1 << 18
+	flagMultiSelect   = 1 << 21
+)
+
+// acroForm collects interactive form fields while the document is laid out.
+type acroForm struct {
+	l        *layout
+	fields   pdf.Array
+	radios   map[string]*radioGroup
+	sigFlags bool            // the form has signature fields
+	tabIndex map[pdf.Ref]int // positive tab indexes of widgets
+}
+
+// radioGroup is the parent field shared by the radio buttons of one group.
+type radioGroup struct {
+	ref     pdf.Ref
+	name    string
+	kids    pdf.Array
+	value   string
+	flags   int
+	tooltip string
+}
+
+func newAcroForm(l *layout) *acroForm {
+	return &acroForm{l: l, radios: make(map[string]*radioGroup), tabIndex: make(map[pdf.Ref]int)}
+}
+
+// addField creates the widget for a form field inside the cell box at
+// (x, y, w, h) in top-down coordinates and attaches it to the page.
+func (f *acroForm) addField(p *page, x, y, w, h float64, st cellStyle, field domain.FormField) {
+	rect := pdf.Array{x + 1, f.l.pdfY(y + h - 1), x + w - 1, f.l.pdfY(y + 1)}
+	switch field.Type {
+	case domain.FormFieldCheckbox:
+		f.addCheckbox(p, f.toggleRect(x, y, w, h, st), field)
+	case domain.FormFieldRadio:
+		f.addRadio(p, f.toggleRect(x, y, w, h, st), field)
+	case domain.FormFieldDropdown, domain.FormFieldListbox:
+		f.addChoice(p, rect, w-2, h-2, st, field)
+	case domain.FormFieldSignature:
+		f.addSignature(p, rect, w-2, h-2, field)
+	default:
+		f.addText(p, rect, w-2, h-2, st, field)
+	}
+}
+
+// drawsOwnText reports whether a field's widget replaces the cell text.
+func drawsOwnText(field domain.FormField) bool {
+	switch field.Type {
+	case domain.FormFieldCheckbox, domain.FormFieldRadio:
+		return false
+	}
+	return true
+}
+
+// minFieldLines returns the number of text lines a field needs to be usable.
+func minFieldLines(field domain.FormField) int {
+	switch field.Type {
+	case domain.FormFieldMultiline:
+		return 3
+	case domain.FormFieldListbox:
+		return max(1, min(len(field.Options), 4))
+	}
+	return 1
+}
+
+// toggleRect places a square checkbox or radio widget inside a cell,
+// honoring the cell alignment horizontally and centering it vertically.
+func (f *acroForm) toggleRect(x, y, w, h float64, st cellStyle) pdf.Array {
+	size := math.Min(toggleFieldSize, math.Min(w, h)-2*cellPadding)
+	left := alignX(x+cellPadding, w-2*cellPadding, size, st.alignment)
+	top := y + (h-size)/2
+	return pdf.Array{left, f.l.pdfY(top + size), left + size, f.l.pdfY(top)}
+}
+
+// addText creates a text field: single-line, multiline or date.
+func (f *acroForm) addText(p *page, rect pdf.Array, w, h float64, st cellStyle, field domain.FormField) {
+	encoded := encodeWinAnsi(field.Value)
+	var ap canvas
+	ap.op("/Tx BMC")
+	ap.save()
+	if field.Type == domain.FormFieldMultiline {
+		for i, line := range wrapText(field.Value, faceRegular, st.size, w-4) {
+			lineEnc := encodeWinAnsi(line)
+			tx := alignX(2, w-4, textWidth(lineEnc, faceRegular, st.size), st.alignment)
+			ap.text(tx, h-2-st.size*0.86-float64(i)*st.leading(), faceRegular, st.size, lineEnc)
+		}
+	} else {
+		tx := alignX(2, w-4, textWidth(encoded, faceRegular, st.size), st.alignment)
+		ap.text(tx, (h-st.size)/2+st.size*0.22, faceRegular, st.size, encoded)
+	}
+	ap.restore()
+	ap.op("EMC")
+
+	widget := f.widget(p, rect)
+	widget["FT"] = pdf.Name("Tx")
+	widget["T"] = pdf.String(field.Name)
+	widget["V"] = pdf.String(encoded)
+	widget["DA"] = pdf.String(fmt.Sprintf("/Helv %s Tf 0 g", pdf.FormatNumber(st.size)))
+	widget["Q"] = quadding(st.alignment)
+	widget["AP"] = pdf.Dict{"N": f.appearance(w, h, ap.bytes())}
+	switch field.Type {
+	case domain.FormFieldMultiline:
+		widget["Ff"] = flagMultiline
+	case domain.FormFieldDate:
+		mask := jsString(field.DateMask())
+		widget["AA"] = pdf.Dict{
+			"K": javaScript("AFDate_KeystrokeEx(" + mask + ");"),
+			"F": javaScript("AFDate_FormatEx(" + mask + ");"),
+		}
+	}
+	if field.MaxLength > 0 {
+		widget["MaxLen"] = field.MaxLength
+	}
+	if field.Pattern != "" {
+		actions, _ := widget["AA"].(pdf.Dict)
+		if actions == nil {
+			actions = pdf.Dict{}
+		}
+		actions["V"] = javaScript(patternScript(field))
+		widget["AA"] = actions
+	}
+
+	f.fields = append(f.fields, f.attach(p, widget, field))
+}
+
+// addChoice creates a combo box or list box. List box appearances show the
+// options with the selections highlighted.
+func (f *acroForm) addChoice(p *page, rect pdf.Array, w, h float64, st cellStyle, field domain.FormField) {
+	selected := field.Selection()
+	isSelected := make(map[string]bool, len(selected))
+	for _, s := range selected {
+		isSelected[s] = true
+	}
+
+	var ap canvas
+	ap.op("/Tx BMC")
+	ap.save()
+	flags := 0
+	if field.Type == domain.FormFieldDropdown {
+		flags = flagCombo
+		if field.Editable {
+			flags |= flagEdit
+		}
+		encoded := encodeWinAnsi(field.Value)
+		ap.text(2, (h-st.size)/2+st.size*0.22, faceRegular, st.size, encoded)
+	} else {
+		if field.MultiSelect {
+			flags = flagMultiSelect
+		}
+		for i, option := range field.Options {
+			top := h - 1 - float64(i)*st.leading()
+			if top-st.leading() < 0 {
+				break
+			}
+			if isSelected[option] {
+				ap.fillRect(1, top-st.leading(), w-2, st.leading(), rgb{0.6, 0.75, 0.95})
+			}
+			ap.text(2, top-st.size*0.86-(st.leading()-st.size)/2, faceRegular, st.size, encodeWinAnsi(option))
+		}
+	}
+	ap.restore()
+	ap.op("EMC")
+
+	opts := make(pdf.Array, len(field.Options))
+	var indices pdf.Array
+	for i, option := range field.Options {
+		opts[i] = pdf.String(encodeWinAnsi(option))
+		if isSelected[option] {
+			indices = append(indices, i)
+		}
+	}
+
+	widget := f.widget(p, rect)
+	widget["FT"] = pdf.Name("Ch")
+	widget["T"] = pdf.String(field.Name)
+	widget["Opt"] = opts
+	widget["DA"] = pdf.String(fmt.Sprintf("/Helv %s Tf 0 g", pdf.FormatNumber(st.size)))
+	widget["AP"] = pdf.Dict{"N": f.appearance(w, h, ap.bytes())}
+	if flags != 0 {
+		widget["Ff"] = flags
+	}
+	switch {
+	case len(selected) > 1:
+		values := make(pdf.Array, len(selected))
+		for i, s := range selected {
+			values[i] = pdf.String(encodeWinAnsi(s))
+		}
+		widget["V"] = values
+		widget["I"] = indices
+	case len(selected) == 1:
+		widget["V"] = pdf.String(encodeWinAnsi(selected[0]))
+		if len(indices) > 0 {
+			widget["I"] = indices
+		}
+	}
+
+	f.fields = append(f.fields, f.attach(p, widget, field))
+}
+
+// addSignature creates an unsigned signature field with a signing line.
+func (f *acroForm) addSignature(p *page, rect pdf.Array, w, h float64, field domain.FormField) {
+	var ap canvas
+	ap.op("0.5 G")
+	ap.line(4, 6, w-4, 6, 0.5)
+
+	widget := f.widget(p, rect)
+	widget["FT"] = pdf.Name("Sig")
+	widget["T"] = pdf.String(field.Name)
+	widget["AP"] = pdf.Dict{"N": f.appearance(w, h, ap.bytes())}
+
+	f.fields = append(f.fields, f.attach(p, widget, field))
+	f.sigFlags = true
+}
+
+// attach applies the attributes shared by all field types, adds the widget
+// to the page and returns its reference.
+func (f *acroForm) attach(p *page, widget pdf.Dict, field domain.FormField) pdf.Ref {
+	if flags := fieldFlags(field); flags != 0 {
+		ff, _ := widget["Ff"].(int)
+		widget["Ff"] = ff | flags
+	}
+	if field.Tooltip != "" {
+		widget["TU"] = pdf.String(encodeWinAnsi(field.Tooltip))
+	}
+	if field.DefaultValue != "" && (field.IsText() || field.IsChoice()) {
+		widget["DV"] = pdf.String(encodeWinAnsi(field.DefaultValue))
+	}
+	ref := f.l.w.Add(widget)
+	p.annots = append(p.annots, ref)
+	if field.TabIndex > 0 {
+		f.tabIndex[ref] = field.TabIndex
+	}
+	return ref
+}
+
+// fieldFlags returns the read-only and required flags of a field.
+func fieldFlags(field domain.FormField) int {
+	flags := 0
+	if field.ReadOnly {
+		flags |= flagReadOnly
+	}
+	if field.Required {
+		flags |= flagRequired
+	}
+	return flags
+}
+
+// patternScript returns a validation script rejecting values that do not
+// match the field pattern. Go and JavaScript regular expressions share the
+// common syntax, so the pattern is passed through as-is.
+func patternScript(field domain.FormField) string {
+	pattern := jsString("^(?:" + field.Pattern + ")$")
+	message := jsString(field.Name + " does not match the expected format.")
+	return "if (event.value !== '' && !new RegExp(" + pattern + ").test(event.value)) " +
+		"{ app.alert(" + message + "); event.rc = false; }"
+}
+
+// tabOrder returns the page annotations ordered for keyboard navigation:
+// widgets with a tab index first, in ascending order, then the rest in
+// document order.
+func (f *acroForm) tabOrder(annots pdf.Array) pdf.Array {
+	ordered := append(pdf.Array(nil), annots...)
+	rank := func(o pdf.Object) int {
+		if ref, ok := o.(pdf.Ref); ok {
+			if i, ok := f.tabIndex[ref]; ok {
+				return i
+			}
+		}
+		return math.MaxInt
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return rank(ordered[i]) < rank(ordered[j]) })
+	return ordered
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '\'':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r > 0xffff:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&b, "\\u%04x\\u%04x", r1, r2)
+		case r < 0x20 || r > 0x7e:
+			fmt.Fprintf(&b, "\\u%04x", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// javaScript returns a JavaScript action.
+func javaScript(script string) pdf.Dict {
+	return pdf.Dict{"S": pdf.Name("JavaScript"), "JS": pdf.String(script)}
+}
+
+// addCheckbox creates a checkbox whose on state is named after the field value.
+func (f *acroForm) addCheckbox(p *page, rect pdf.Array, field domain.FormField) {
+	size := rect[2].(float64) - rect[0].(float64)
+	on := field.OnState()
+
+	widget := f.widget(p, rect)
+	widget["FT"] = pdf.Name("Btn")
+	widget["T"] = pdf.String(field.Name)
+	widget["DA"] = pdf.String("/ZaDb 0 Tf 0 g")
+	widget["MK"] = pdf.Dict{"CA": pdf.String("4"), "BC": pdf.Array{0.0}}
+	widget["AP"] = pdf.Dict{"N": pdf.Dict{
+		pdf.Name(on):         f.appearance(size, size, checkboxAppearance(size, true)),
+		domain.FieldStateOff: f.appearance(size, size, checkboxAppearance(size, false)),
+	}}
+	state := pdf.Name(domain.FieldStateOff)
+	if field.Checked {
+		state = pdf.Name(on)
+	}
+	widget["V"] = state
+	widget["AS"] = state
+
+	f.fields = append(f.fields, f.attach(p, widget, field))
+}
+
+// addRadio creates a radio button widget as a kid of its group field.
+func (f *acroForm) addRadio(p *page, rect pdf.Array, field domain.FormField) {
+	groupName := field.GroupName
+	if groupName == "" {
+		groupName = field.Name
+	}
+	group, ok := f.radios[groupName]
+	if !ok {
+		group = &radioGroup{ref: f.l.w.Alloc(), name: groupName}
+		f.radios[groupName] = group
+		f.fields = append(f.fields, group.ref)
+	}
+
+	size := rect[2].(float64) - rect[0].(float64)
+	on := field.OnState()
+
+	widget := f.widget(p, rect)
+	widget["Parent"] = group.ref
+	widget["MK"] = pdf.Dict{"CA": pdf.String("l"), "BC": pdf.Array{0.0}}
+	widget["AP"] = pdf.Dict{"N": pdf.Dict{
+		pdf.Name(on):         f.appearance(size, size, radioAppearance(size, true)),
+		domain.FieldStateOff: f.appearance(size, size, radioAppearance(size, false)),
+	}}
+	widget["AS"] = pdf.Name(domain.FieldStateOff)
+	if field.Checked {
+		widget["AS"] = pdf.Name(on)
+		group.value = on
+	}
+
+	group.flags |= fieldFlags(field)
+	if field.Tooltip != "" {
+		group.tooltip = field.Tooltip
+	}
+	group.kids = append(group.kids, f.attach(p, widget, domain.FormField{TabIndex: field.TabIndex}))
+}
+
+// widget returns the dictionary entries shared by all widget annotations.
+func (f *acroForm) widget(p *page, rect pdf.Array) pdf.Dict {
+	return pdf.Dict{
+		"Type":    pdf.Name("Annot"),
+		"Subtype": pdf.Name("Widget"),
+		"Rect":    rect,
+		"P":       p.ref,
+		"F":       4,
+	}
+}
+
+// appearance stores an appearance stream form XObject.
+func (f *acroForm) appearance(w, h float64, content []byte) pdf.Ref {
+	return f.l.w.Add(pdf.FlateStream(pdf.Dict{
+		"Type":      pdf.Name("XObject"),
+		"Subtype":   pdf.Name("Form"),
+		"BBox":      pdf.Array{0.0, 0.0, w, h},
+		"Resources": f.resources(),
+	}, content))
+}
+
+// resources returns the font resources available to form appearances.
+func (f *acroForm) resources() pdf.Dict {
+	return pdf.Dict{"Font": pdf.Dict{
+		"Helv": f.l.fonts[faceRegular.resourceName()],
+		"ZaDb": f.l.fonts["ZaDb"],
+	}}
+}
+
+// finish writes the radio group fields and returns the AcroForm dictionary,
+// or nil when the document has no fields.
+func (f *acroForm) finish() pdf.Object {
+	if len(f.fields) == 0 {
+		return nil
+	}
+	for _, group := range f.radios {
+		value := pdf.Name(domain.FieldStateOff)
+		if group.value != "" {
+			value = pdf.Name(group.value)
+		}
+		dict := pdf.Dict{
+			"FT":   pdf.Name("Btn"),
+			"Ff":   flagRadio | flagNoToggleToOff | group.flags,
+			"T":    pdf.String(group.name),
+			"V":    value,
+			"Kids": group.kids,
+		}
+		if group.tooltip != "" {
+			dict["TU"] = pdf.String(encodeWinAnsi(group.tooltip))
+		}
+		f.l.w.Set(group.ref, dict)
+	}
+	form := pdf.Dict{
+		"Fields": f.fields,
+		"DA":     pdf.String("/Helv 0 Tf 0 g"),
+		"DR":     f.resources(),
+	}
+	if f.sigFlags {
+		form["SigFlags"] = 1 // SignaturesExist
+	}
+	return form
+}
+
+// quadding maps an alignment to the form field Q value.
+func quadding(align domain.Alignment) int {
+	switch align {
+	case domain.AlignCenter:
+		return 1
+	case domain.AlignRight:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// checkboxAppearance draws a square box, with a check mark when on.
+func checkboxAppearance(size float64, on bool) []byte {
+	var c canvas
+	c.op("0 G 0.5 w 0.25 0.25", size-0.5, size-0.5, "re S")
+	if on {
+		c.op("BT /ZaDb", size*0.8, "Tf", size*0.12, size*0.2, "Td (4) Tj ET")
+	}
+	return c.bytes()
+}
+
+// radioAppearance draws a circle, with a filled dot when on.
+func radioAppearance(size float64, on bool) []byte {
+	var c canvas
+	r := size / 2
+	c.op("0 G 0.5 w")
+	c.circle(r, r, r-0.25)
+	c.op("S")
+	if on {
+		c.op("0 g")
+		c.circle(r, r, r*0.5)
+		c.op("f")
+	}
+	return c.bytes()
+}
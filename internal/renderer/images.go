@@ -0,0 +1,204 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strconv"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// xobject is an image embedded once and referenced by name from any page.
+type xobject struct {
+	name   string
+	width  int
+	height int
+}
+
+// loadImage embeds an image on first use and returns its XObject. Inline
+// data is decoded; a path is read from the local disk.
+func (l *layout) loadImage(img domain.Image) (*xobject, error) {
+	key := img.Path
+	if img.Data != "" {
+		key = img.Data
+	}
+	if x, ok := l.images[key]; ok {
+		return x, nil
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case img.Data != "":
+		data, err = img.Bytes()
+	case img.Path != "":
+		data, err = os.ReadFile(img.Path)
+		if err != nil {
+			err = fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+		}
+	default:
+		err = fmt.Errorf("%w: either path or data is required", domain.ErrInvalidImage)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, width, height, err := domain.DecodeImageConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	var stream *pdf.Stream
+	if mimeType == domain.MimeTypeJPEG {
+		stream, err = jpegStream(data)
+	} else {
+		stream, err = l.rasterStream(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xobject{name: "Im" + strconv.Itoa(len(l.images)+1), width: width, height: height}
+	l.xobjects[pdf.Name(x.name)] = l.w.Add(stream)
+	l.images[key] = x
+	return x, nil
+}
+
+// jpegStream embeds JPEG data as-is using the DCTDecode filter.
+func jpegStream(data []byte) (*pdf.Stream, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	colorSpace := "DeviceRGB"
+	switch cfg.ColorModel {
+	case color.GrayModel, color.Gray16Model:
+		colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		colorSpace = "DeviceCMYK"
+	}
+	return &pdf.Stream{
+		Dict: pdf.Dict{
+			"Type":             pdf.Name("XObject"),
+			"Subtype":          pdf.Name("Image"),
+			"Width":            cfg.Width,
+			"Height":           cfg.Height,
+			"ColorSpace":       pdf.Name(colorSpace),
+			"BitsPerComponent": 8,
+			"Filter":           pdf.Name("DCTDecode"),
+		},
+		Data: data,
+	}, nil
+}
+
+// rasterStream decodes a PNG or GIF image to 8-bit RGB samples. Transparency
+// is carried by a separate soft mask.
+func (l *layout) rasterStream(data []byte) (*pdf.Stream, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	b := src.Bounds()
+	rgb := make([]byte, 0, b.Dx()*b.Dy()*3)
+	alpha := make([]byte, 0, b.Dx()*b.Dy())
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			opaque = opaque && c.A == 0xff
+		}
+	}
+
+	dict := pdf.Dict{
+		"Type":             pdf.Name("XObject"),
+		"Subtype":          pdf.Name("Image"),
+		"Width":            b.Dx(),
+		"Height":           b.Dy(),
+		"ColorSpace":       pdf.Name("DeviceRGB"),
+		"BitsPerComponent": 8,
+	}
+	if !opaque {
+		dict["SMask"] = l.w.Add(pdf.FlateStream(pdf.Dict{
+			"Type":             pdf.Name("XObject"),
+			"Subtype":          pdf.Name("Image"),
+			"Width":            b.Dx(),
+			"Height":           b.Dy(),
+			"ColorSpace":       pdf.Name("DeviceGray"),
+			"BitsPerComponent": 8,
+		}, alpha))
+	}
+	return pdf.FlateStream(dict, rgb), nil
+}
+
+// imageSize returns the drawn size of an image in points. Missing dimensions
+// come from the pixel size, keeping the aspect ratio when one is given.
+func (l *layout) imageSize(img domain.Image) (float64, float64, error) {
+	x, err := l.loadImage(img)
+	if err != nil {
+		return 0, 0, err
+	}
+	w, h := img.Width.Points(), img.Height.Points()
+	px, py := float64(x.width), float64(x.height)
+	switch {
+	case w > 0 && h > 0:
+	case w > 0:
+		h = w * py / px
+	case h > 0:
+		w = h * px / py
+	default:
+		w, h = px, py
+	}
+	return w, h, nil
+}
+
+// drawImage draws an image with its top-left corner at (x, y) in top-down
+// coordinates on the given canvas.
+func (l *layout) drawImage(c *canvas, img domain.Image, x, y, w, h float64) {
+	xo, err := l.loadImage(img)
+	if err != nil {
+		l.fail(err)
+		return
+	}
+	c.image(xo.name, x, l.pdfY(y+h), w, h)
+}
+
+// drawCellImage draws an image scaled to fit inside the padded cell box,
+// aligned horizontally by the cell style and centered vertically.
+func (l *layout) drawCellImage(x, y, w, h float64, st cellStyle, img domain.Image) {
+	iw, ih, err := l.imageSize(img)
+	if err != nil {
+		l.fail(err)
+		return
+	}
+	fw, fh := fitBox(iw, ih, w-2*cellPadding, h-2*cellPadding)
+	ix := alignX(x+cellPadding, w-2*cellPadding, fw, st.alignment)
+	iy := y + (h-fh)/2
+	l.drawImage(&l.cur.canvas, img, ix, iy, fw, fh)
+}
+
+// fitBox scales a width and height down to fit a box, keeping the aspect
+// ratio. A zero box side is unbounded.
+func fitBox(w, h, maxW, maxH float64) (float64, float64) {
+	fit := domain.Image{Width: domain.Pt(w), Height: domain.Pt(h)}.Fit(domain.Pt(maxW), domain.Pt(maxH))
+	return fit.Width.Points(), fit.Height.Points()
+}
+
+// drawDocumentImages draws the document-level images on the first page.
+func (l *layout) drawDocumentImages() {
+	if len(l.pages) == 0 {
+		return
+	}
+	for _, img := range l.doc.Images {
+		w, h, err := l.imageSize(img)
+		if err != nil {
+			l.fail(err)
+			continue
+		}
+		l.drawImage(&l.pages[0].canvas, img, img.X.Points(), img.Y.Points(), w, h)
+	}
+}
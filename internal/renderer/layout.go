@@ -0,0 +1,218 @@
+package renderer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Layout constants, in points.
+const (
+	defaultMargin = 36
+	borderInset   = 24
+	headerSpace   = 24
+	footerSpace   = 24
+	cellPadding   = 3
+	watermarkSize = 60
+)
+
+// page holds the content and annotations of one output page.
+type page struct {
+	ref    pdf.Ref
+	canvas canvas
+	annots pdf.Array
+}
+
+// layout flows a document onto pages from top to bottom.
+type layout struct {
+	doc    *domain.Document
+	body   []domain.Block // doc.Blocks()
+	w      *pdf.Writer
+	width  float64
+	height float64
+	pages  []*page
+	cur    *page
+	y      float64 // distance from the top edge to the next free position
+	margin domain.Margins
+	fonts  map[string]pdf.Ref
+	form   *acroForm
+
+	images   map[string]*xobject
+	xobjects pdf.Dict
+	anchors  pdf.Dict       // named destinations
+	headings []pdf.Array    // destinations of the headings in document order
+	contents []contentsLine // table of contents entries awaiting page numbers
+	clip     *[2]float64    // top-down span links are limited to while rows are split
+	err      error          // first error hit while drawing, returned by Send
+}
+
+func newLayout(doc *domain.Document, width, height float64) *layout {
+	l := &layout{
+		doc:    doc,
+		body:   doc.Blocks(),
+		w:      pdf.NewWriter(),
+		width:  width,
+		height: height,
+		margin: doc.Config.PageMargins(defaultMargin),
+		fonts:  make(map[string]pdf.Ref),
+
+		images:   make(map[string]*xobject),
+		xobjects: make(pdf.Dict),
+		anchors:  make(pdf.Dict),
+	}
+	for _, face := range []fontFace{faceRegular, faceBold, faceItalic, faceBoldItalic} {
+		l.fonts[face.resourceName()] = l.w.Add(pdf.Dict{
+			"Type":     pdf.Name("Font"),
+			"Subtype":  pdf.Name("Type1"),
+			"BaseFont": pdf.Name(face.baseFont()),
+			"Encoding": pdf.Name("WinAnsiEncoding"),
+		})
+	}
+	l.fonts["ZaDb"] = l.w.Add(pdf.Dict{
+		"Type":     pdf.Name("Font"),
+		"Subtype":  pdf.Name("Type1"),
+		"BaseFont": pdf.Name("ZapfDingbats"),
+	})
+	l.form = newAcroForm(l)
+	return l
+}
+
+// fail records the first error hit while drawing.
+func (l *layout) fail(err error) {
+	if l.err == nil {
+		l.err = err
+	}
+}
+
+// top returns the y position where content starts on the current page,
+// below the header when the page has one.
+func (l *layout) top() float64 {
+	if l.hasHeader(len(l.pages)) {
+		return l.margin.Top.Points() + headerSpace
+	}
+	return l.margin.Top.Points()
+}
+
+// bottom returns the lowest y position content may reach on the current
+// page, above the footer when the page has one.
+func (l *layout) bottom() float64 {
+	if l.hasFooter(len(l.pages)) {
+		return l.height - l.margin.Bottom.Points() - footerSpace
+	}
+	return l.height - l.margin.Bottom.Points()
+}
+
+// left returns the x position where content starts.
+func (l *layout) left() float64 {
+	return l.margin.Left.Points()
+}
+
+// contentWidth returns the usable width between the side margins.
+func (l *layout) contentWidth() float64 {
+	return l.width - l.margin.Left.Points() - l.margin.Right.Points()
+}
+
+// pdfY converts a top-down y position to PDF user space.
+func (l *layout) pdfY(y float64) float64 {
+	return l.height - y
+}
+
+// clipped reports whether the span from top to bottom lies outside the part
+// of split table rows being drawn.
+func (l *layout) clipped(top, bottom float64) bool {
+	return l.clip != nil && (bottom <= l.clip[0] || top >= l.clip[1])
+}
+
+// newPage starts a new page and draws the per-page decorations.
+func (l *layout) newPage() {
+	p := &page{ref: l.w.Alloc()}
+	l.pages = append(l.pages, p)
+	l.cur = p
+	l.y = l.top()
+	l.drawWatermark()
+	l.drawPageBorder()
+}
+
+// ensureSpace starts a new page when h points do not fit on the current one.
+func (l *layout) ensureSpace(h float64) {
+	if l.cur == nil {
+		l.newPage()
+		return
+	}
+	if l.y+h > l.bottom() && l.y > l.top() {
+		l.newPage()
+	}
+}
+
+// drawWatermark draws the configured watermark diagonally across the page.
+func (l *layout) drawWatermark() {
+	text := l.doc.Config.Watermark
+	if text == "" {
+		return
+	}
+	encoded := encodeWinAnsi(text)
+	w := textWidth(encoded, faceBold, watermarkSize)
+	// Start so that the rotated text is centered on the page.
+	const cos45 = 0.70710678
+	x := l.width/2 - cos45*(w/2) + cos45*(watermarkSize/3)
+	y := l.height/2 - cos45*(w/2) - cos45*(watermarkSize/3)
+
+	c := &l.cur.canvas
+	c.save()
+	c.gray(0.85)
+	c.rotatedText(x, y, 45, faceBold, watermarkSize, encoded)
+	c.restore()
+}
+
+// drawPageBorder draws the page border described by Config.PageBorder.
+func (l *layout) drawPageBorder() {
+	borders := parseBorders(l.doc.Config.PageBorder)
+	left, right := float64(borderInset), l.width-borderInset
+	top, bottom := l.pdfY(borderInset), l.pdfY(l.height-borderInset)
+	drawBorders(&l.cur.canvas, left, top, right, bottom, borders)
+}
+
+// parseBorders decodes a "top:right:bottom:left" width string.
+func parseBorders(s string) [4]float64 {
+	var borders [4]float64
+	for i, part := range strings.Split(s, ":") {
+		if i >= 4 {
+			break
+		}
+		if w, err := strconv.ParseFloat(strings.TrimSpace(part), 64); err == nil && w > 0 {
+			borders[i] = w
+		}
+	}
+	return borders
+}
+
+// drawBorders strokes the sides of a rectangle whose widths are non-zero.
+// The rectangle is given in PDF user space.
+func drawBorders(c *canvas, left, top, right, bottom float64, borders [4]float64) {
+	if borders[0] > 0 {
+		c.line(left, top, right, top, borders[0])
+	}
+	if borders[1] > 0 {
+		c.line(right, top, right, bottom, borders[1])
+	}
+	if borders[2] > 0 {
+		c.line(left, bottom, right, bottom, borders[2])
+	}
+	if borders[3] > 0 {
+		c.line(left, top, left, bottom, borders[3])
+	}
+}
+
+// alignX returns the x position of a run of the given width inside a box.
+func alignX(left, boxWidth, runWidth float64, align domain.Alignment) float64 {
+	switch align {
+	case domain.AlignCenter:
+		return left + (boxWidth-runWidth)/2
+	case domain.AlignRight:
+		return left + boxWidth - runWidth
+	default:
+		return left
+	}
+}
@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// addAnchor names the current position as a destination for internal links
+// and bookmarks. The first position recorded for a name wins.
+func (l *layout) addAnchor(name string) {
+	if _, ok := l.anchors[pdf.Name(name)]; ok {
+		return
+	}
+	l.anchors[pdf.Name(name)] = l.dest()
+}
+
+// dest returns an explicit destination of the current position.
+func (l *layout) dest() pdf.Array {
+	return pdf.Array{l.cur.ref, pdf.Name("XYZ"), l.left(), l.pdfY(l.y), nil}
+}
+
+// addLink adds a link annotation over a rectangle given in PDF user space,
+// limited to the clipped span while table rows are split. The target is an
+// absolute URL or an internal link to a named anchor.
+func (l *layout) addLink(x1, y1, x2, y2 float64, target string) {
+	if l.clip != nil {
+		y1, y2 = max(y1, l.pdfY(l.clip[1])), min(y2, l.pdfY(l.clip[0]))
+		if y1 >= y2 {
+			return
+		}
+	}
+	if anchor, ok := domain.LinkAnchor(target); ok {
+		l.addLinkAnnot(l.cur, pdf.Array{x1, y1, x2, y2}, "Dest", pdf.Name(anchor))
+	} else {
+		action := pdf.Dict{"S": pdf.Name("URI"), "URI": pdf.String(target)}
+		l.addLinkAnnot(l.cur, pdf.Array{x1, y1, x2, y2}, "A", action)
+	}
+}
+
+// addLinkAnnot adds a borderless link annotation to a page whose target is
+// given by a Dest or A entry.
+func (l *layout) addLinkAnnot(p *page, rect pdf.Array, key pdf.Name, target pdf.Object) {
+	p.annots = append(p.annots, l.w.Add(pdf.Dict{
+		"Type":    pdf.Name("Annot"),
+		"Subtype": pdf.Name("Link"),
+		"Rect":    rect,
+		"Border":  pdf.Array{0, 0, 0},
+		key:       target,
+	}))
+}
+
+// outline writes the bookmarks as the document outline and returns its
+// reference. Bookmarks to anchors that were never drawn do nothing when
+// clicked.
+func (l *layout) outline(bookmarks []domain.Bookmark) pdf.Ref {
+	root := l.w.Alloc()
+	first, last, count := l.outlineItems(root, bookmarks)
+	l.w.Set(root, pdf.Dict{
+		"Type":  pdf.Name("Outlines"),
+		"First": first,
+		"Last":  last,
+		"Count": count,
+	})
+	return root
+}
+
+// outlineItems writes the items of one outline level under parent and
+// returns the first and last item and the number of items shown, which
+// includes the open descendants.
+func (l *layout) outlineItems(parent pdf.Ref, bookmarks []domain.Bookmark) (first, last pdf.Ref, count int) {
+	refs := make([]pdf.Ref, len(bookmarks))
+	for i := range bookmarks {
+		refs[i] = l.w.Alloc()
+	}
+	for i, b := range bookmarks {
+		item := pdf.Dict{
+			"Title":  pdf.TextString(b.Title),
+			"Parent": parent,
+		}
+		if _, ok := l.anchors[pdf.Name(b.Anchor)]; ok {
+			item["Dest"] = pdf.Name(b.Anchor)
+		}
+		if i > 0 {
+			item["Prev"] = refs[i-1]
+		}
+		if i < len(refs)-1 {
+			item["Next"] = refs[i+1]
+		}
+		if len(b.Children) > 0 {
+			firstChild, lastChild, n := l.outlineItems(refs[i], b.Children)
+			item["First"], item["Last"], item["Count"] = firstChild, lastChild, n
+			count += n
+		}
+		l.w.Set(refs[i], item)
+	}
+	return refs[0], refs[len(refs)-1], count + len(refs)
+}
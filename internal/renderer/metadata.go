@@ -0,0 +1,137 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// producer is written to the Producer entry of every document.
+const producer = "gopdfsuit-client"
+
+// docInfo holds the resolved document information of a rendered document.
+type docInfo struct {
+	meta    domain.Metadata
+	title   string
+	created time.Time
+}
+
+// resolveInfo merges the document metadata with the title text and the
+// rendering time.
+func resolveInfo(doc *domain.Document, now time.Time) docInfo {
+	info := docInfo{title: doc.Title.Text, created: now}
+	if doc.Metadata != nil {
+		info.meta = *doc.Metadata
+		if info.meta.Title != "" {
+			info.title = info.meta.Title
+		}
+		if info.meta.CreationDate != nil {
+			info.created = *info.meta.CreationDate
+		}
+	}
+	return info
+}
+
+// dict builds the document information dictionary.
+func (i docInfo) dict() pdf.Dict {
+	d := pdf.Dict{
+		"Producer":     pdf.TextString(producer),
+		"CreationDate": pdf.Date(i.created),
+	}
+	set := func(key pdf.Name, value string) {
+		if value != "" {
+			d[key] = pdf.TextString(value)
+		}
+	}
+	set("Title", i.title)
+	set("Author", i.meta.Author)
+	set("Subject", i.meta.Subject)
+	set("Keywords", strings.Join(i.meta.Keywords, ", "))
+	set("Creator", i.meta.Creator)
+	for _, key := range i.customKeys() {
+		set(pdf.Name(key), i.meta.Custom[key])
+	}
+	return d
+}
+
+// customKeys returns the valid custom property names in sorted order.
+func (i docInfo) customKeys() []string {
+	keys := make([]string, 0, len(i.meta.Custom))
+	for key := range i.meta.Custom {
+		if domain.ValidCustomKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// xmp builds the XMP metadata packet mirroring the information dictionary.
+func (i docInfo) xmp() []byte {
+	var b bytes.Buffer
+	esc := func(s string) string {
+		var e bytes.Buffer
+		_ = xml.EscapeText(&e, []byte(s))
+		return e.String()
+	}
+	line := func(parts ...string) {
+		b.WriteString(strings.Join(parts, ""))
+		b.WriteByte('\n')
+	}
+	list := func(tag, kind string, items []string, lang bool) {
+		if len(items) == 0 {
+			return
+		}
+		line("<", tag, "><rdf:", kind, ">")
+		for _, item := range items {
+			if lang {
+				line(`<rdf:li xml:lang="x-default">`, esc(item), "</rdf:li>")
+			} else {
+				line("<rdf:li>", esc(item), "</rdf:li>")
+			}
+		}
+		line("</rdf:", kind, "></", tag, ">")
+	}
+	nonEmpty := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return []string{s}
+	}
+
+	line(`<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>`)
+	line(`<x:xmpmeta xmlns:x="adobe:ns:meta/">`)
+	line(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">`)
+	line(`<rdf:Description rdf:about=""`,
+		` xmlns:dc="http://purl.org/dc/elements/1.1/"`,
+		` xmlns:pdf="http://ns.adobe.com/pdf/1.3/"`,
+		` xmlns:xmp="http://ns.adobe.com/xap/1.0/"`,
+		` xmlns:pdfx="http://ns.adobe.com/pdfx/1.3/">`)
+	line("<dc:format>application/pdf</dc:format>")
+	list("dc:title", "Alt", nonEmpty(i.title), true)
+	list("dc:creator", "Seq", nonEmpty(i.meta.Author), false)
+	list("dc:description", "Alt", nonEmpty(i.meta.Subject), true)
+	list("dc:subject", "Bag", i.meta.Keywords, false)
+	list("dc:language", "Bag", nonEmpty(i.meta.Language), false)
+	if len(i.meta.Keywords) > 0 {
+		line("<pdf:Keywords>", esc(strings.Join(i.meta.Keywords, ", ")), "</pdf:Keywords>")
+	}
+	line("<pdf:Producer>", producer, "</pdf:Producer>")
+	if i.meta.Creator != "" {
+		line("<xmp:CreatorTool>", esc(i.meta.Creator), "</xmp:CreatorTool>")
+	}
+	line("<xmp:CreateDate>", i.created.Format(time.RFC3339), "</xmp:CreateDate>")
+	for _, key := range i.customKeys() {
+		line("<pdfx:", key, ">", esc(i.meta.Custom[key]), "</pdfx:", key, ">")
+	}
+	line("</rdf:Description>")
+	line("</rdf:RDF>")
+	line("</x:xmpmeta>")
+	line(`<?xpacket end="w"?>`)
+	return b.Bytes()
+}
@@ -0,0 +1,143 @@
+// Package renderer provides an in-process, pure Go PDF renderer for documents.
+package renderer
+
+import (
+	"context"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Renderer renders documents to PDF locally without contacting a server.
+// It implements domain.DocumentSender.
+type Renderer struct {
+	now          func() time.Time
+	skipValidate bool
+}
+
+// New creates a new Renderer.
+func New() *Renderer {
+	return &Renderer{now: time.Now}
+}
+
+// SetValidation enables or disables the Document.Validate check that Send
+// runs before rendering. Validation is enabled by default.
+func (r *Renderer) SetValidation(enabled bool) {
+	r.skipValidate = !enabled
+}
+
+// Send renders the document and returns the PDF bytes.
+func (r *Renderer) Send(ctx context.Context, doc *domain.Document) ([]byte, error) {
+	if doc == nil {
+		return nil, domain.ErrDocumentNil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !r.skipValidate {
+		if err := doc.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	width, height, err := doc.Config.PageDimensions()
+	if err != nil {
+		return nil, err
+	}
+
+	l := newLayout(doc, width.Points(), height.Points())
+	l.newPage()
+	l.renderTitle(l.keepHeight(l.body, 0))
+	for i, block := range l.body {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		l.renderBlock(block, l.keepHeight(l.body, i+1))
+	}
+	l.drawContents()
+	l.drawShapes()
+	l.drawDocumentImages()
+	now := r.now()
+	l.drawTextBoxes(now)
+	l.drawPageSections(now)
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	return r.write(l, now)
+}
+
+// write assembles the page tree, catalog and information dictionary, and
+// encrypts the document when it has security settings.
+func (r *Renderer) write(l *layout, now time.Time) ([]byte, error) {
+	w := l.w
+	pagesRef := w.Alloc()
+	res := pdf.Dict{"Font": pdf.Dict{
+		"F1": l.fonts["F1"],
+		"F2": l.fonts["F2"],
+		"F3": l.fonts["F3"],
+		"F4": l.fonts["F4"],
+	}}
+	if len(l.xobjects) > 0 {
+		res["XObject"] = l.xobjects
+	}
+	resources := w.Add(res)
+
+	kids := make(pdf.Array, 0, len(l.pages))
+	for _, p := range l.pages {
+		content := w.Add(pdf.FlateStream(nil, p.canvas.bytes()))
+		dict := pdf.Dict{
+			"Type":      pdf.Name("Page"),
+			"Parent":    pagesRef,
+			"MediaBox":  pdf.Array{0.0, 0.0, l.width, l.height},
+			"Resources": resources,
+			"Contents":  content,
+		}
+		if len(p.annots) > 0 {
+			dict["Annots"] = l.form.tabOrder(p.annots)
+		}
+		w.Set(p.ref, dict)
+		kids = append(kids, p.ref)
+	}
+	w.Set(pagesRef, pdf.Dict{
+		"Type":  pdf.Name("Pages"),
+		"Kids":  kids,
+		"Count": len(kids),
+	})
+
+	info := resolveInfo(l.doc, now)
+	catalog := pdf.Dict{
+		"Type":  pdf.Name("Catalog"),
+		"Pages": pagesRef,
+	}
+	if form := l.form.finish(); form != nil {
+		catalog["AcroForm"] = form
+	}
+	if len(l.anchors) > 0 {
+		catalog["Dests"] = w.Add(l.anchors)
+	}
+	if len(l.doc.Bookmarks) > 0 {
+		catalog["Outlines"] = l.outline(l.doc.Bookmarks)
+		catalog["PageMode"] = pdf.Name("UseOutlines")
+	}
+	if l.doc.Metadata != nil {
+		catalog["Metadata"] = w.Add(&pdf.Stream{
+			Dict: pdf.Dict{"Type": pdf.Name("Metadata"), "Subtype": pdf.Name("XML")},
+			Data: info.xmp(),
+		})
+		if info.meta.Language != "" {
+			catalog["Lang"] = pdf.TextString(info.meta.Language)
+		}
+	}
+	trailer := pdf.Dict{}
+	if sec := l.doc.Config.Security; sec != nil {
+		if err := encrypt(w, *sec, catalog, trailer); err != nil {
+			return nil, err
+		}
+	}
+	trailer["Root"] = w.Add(catalog)
+	trailer["Info"] = w.Add(info.dict())
+
+	return w.Bytes(trailer), nil
+}
@@ -0,0 +1,289 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+const testProps = "font1:9:000:left:1:1:1:1"
+
+// render renders doc and parses the result.
+func render(t *testing.T, doc *domain.Document) *pdf.Reader {
+	t.Helper()
+	r := New()
+	r.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	out, err := r.Send(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	pr, err := pdf.NewReader(out)
+	if err != nil {
+		t.Fatalf("parsing the output: %v", err)
+	}
+	return pr
+}
+
+// catalog returns the document catalog.
+func catalog(t *testing.T, r *pdf.Reader) pdf.Dict {
+	t.Helper()
+	cat, err := r.ResolveDict(r.Trailer()["Root"])
+	if err != nil {
+		t.Fatalf("catalog: %v", err)
+	}
+	return cat
+}
+
+// pageContents returns the decoded content stream of every page in order.
+func pageContents(t *testing.T, r *pdf.Reader) [][]byte {
+	t.Helper()
+	pages, err := r.ResolveDict(catalog(t, r)["Pages"])
+	if err != nil {
+		t.Fatalf("page tree: %v", err)
+	}
+	kids, err := r.ResolveArray(pages["Kids"])
+	if err != nil {
+		t.Fatalf("page tree kids: %v", err)
+	}
+	if count := pages["Count"]; count != len(kids) {
+		t.Errorf("page tree Count = %v for %d kids", count, len(kids))
+	}
+	var contents [][]byte
+	for i, kid := range kids {
+		page, err := r.ResolveDict(kid)
+		if err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		obj, err := r.Resolve(page["Contents"])
+		if err != nil {
+			t.Fatalf("page %d contents: %v", i, err)
+		}
+		s, ok := obj.(*pdf.Stream)
+		if !ok {
+			t.Fatalf("page %d contents is %T, want a stream", i, obj)
+		}
+		data, err := pdf.DecodeStream(s)
+		if err != nil {
+			t.Fatalf("page %d contents: %v", i, err)
+		}
+		contents = append(contents, data)
+	}
+	return contents
+}
+
+// fieldNames returns the names of the AcroForm fields, including those of
+// kids that have their own name, in the order of the Fields array.
+func fieldNames(t *testing.T, r *pdf.Reader) []string {
+	t.Helper()
+	form, err := r.ResolveDict(catalog(t, r)["AcroForm"])
+	if err != nil {
+		t.Fatalf("AcroForm: %v", err)
+	}
+	var names []string
+	var walk func(fields pdf.Object)
+	walk = func(fields pdf.Object) {
+		arr, err := r.ResolveArray(fields)
+		if err != nil {
+			t.Fatalf("fields: %v", err)
+		}
+		for _, f := range arr {
+			field, err := r.ResolveDict(f)
+			if err != nil {
+				t.Fatalf("field: %v", err)
+			}
+			if name, ok := field["T"].(pdf.String); ok {
+				names = append(names, string(name))
+			}
+			if kids, ok := field["Kids"]; ok {
+				walk(kids)
+			}
+		}
+	}
+	walk(form["Fields"])
+	return names
+}
+
+// shown counts the times text is drawn in a content stream.
+func shown(content []byte, text string) int {
+	return bytes.Count(content, []byte(pdf.Literal([]byte(text))+" Tj"))
+}
+
+// textTable returns a one-column table of rows of the given height.
+func textTable(rows, height int, prefix string) domain.Table {
+	t := domain.Table{MaxColumns: 1, ColumnWidths: []float64{1}}
+	for i := 1; i <= rows; i++ {
+		t.Rows = append(t.Rows, domain.Row{Height: height, Cells: []domain.Cell{{Props: testProps, Text: fmt.Sprintf("%s %d", prefix, i)}}})
+	}
+	return t
+}
+
+func TestRenderSample(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "sample.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc domain.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	r := render(t, &doc)
+
+	contents := pageContents(t, r)
+	if len(contents) == 0 {
+		t.Fatal("no pages")
+	}
+	var all []byte
+	for _, c := range contents {
+		all = append(all, c...)
+	}
+	for _, text := range []string{"SECTION A: PATIENT INFORMATION", "CONFIDENTIAL PATIENT INFORMATION - PROTECTED UNDER HIPAA"} {
+		if shown(all, text) == 0 {
+			t.Errorf("%q is not drawn", text)
+		}
+	}
+
+	var want []string
+	for _, f := range doc.FormFields() {
+		name := f.Name
+		if f.Type == domain.FormFieldRadio && f.GroupName != "" {
+			name = f.GroupName
+		}
+		if !slices.Contains(want, name) {
+			want = append(want, name)
+		}
+	}
+	got := fieldNames(t, r)
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("AcroForm fields\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestRenderPageCount(t *testing.T) {
+	const rowHeight = 50
+
+	// The space for rows on a page without header or footer.
+	probe := newLayout(&domain.Document{}, 595, 842)
+	probe.newPage()
+	perPage := int((probe.bottom() - probe.top()) / rowHeight)
+
+	tests := []struct {
+		name string
+		rows int
+		want int
+	}{
+		{"one row", 1, 1},
+		{"one full page", perPage, 1},
+		{"one row more than a page", perPage + 1, 2},
+		{"three pages", 2*perPage + 1, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &domain.Document{
+				Config: domain.Config{Page: string(domain.PageSizeA4)},
+				Tables: []domain.Table{textTable(tt.rows, rowHeight, "Row")},
+			}
+			contents := pageContents(t, render(t, doc))
+			if len(contents) != tt.want {
+				t.Fatalf("%d rows of %dpt: %d pages, want %d", tt.rows, rowHeight, len(contents), tt.want)
+			}
+			drawn := 0
+			for _, c := range contents {
+				for i := 1; i <= tt.rows; i++ {
+					drawn += shown(c, fmt.Sprintf("Row %d", i))
+				}
+			}
+			if drawn != tt.rows {
+				t.Errorf("%d rows drawn, want %d", drawn, tt.rows)
+			}
+		})
+	}
+}
+
+func TestRenderRepeatedHeaderRows(t *testing.T) {
+	table := textTable(80, 30, "Item")
+	header := []domain.Row{
+		{Cells: []domain.Cell{{Props: "font1:9:100:left:1:1:1:1", Text: "Invoice lines"}}},
+		{Cells: []domain.Cell{{Props: "font1:9:100:left:1:1:1:1", Text: "Description"}}},
+	}
+	table.Rows = append(header, table.Rows...)
+	table.HeaderRows = len(header)
+	doc := &domain.Document{
+		Config: domain.Config{Page: string(domain.PageSizeA4)},
+		Tables: []domain.Table{table},
+	}
+
+	contents := pageContents(t, render(t, doc))
+	if len(contents) < 3 {
+		t.Fatalf("%d pages, want a table over at least 3", len(contents))
+	}
+	seen := make(map[string]int)
+	for i, c := range contents {
+		for _, row := range header {
+			if n := shown(c, row.Cells[0].Text); n != 1 {
+				t.Errorf("page %d draws header %q %d times, want once", i+1, row.Cells[0].Text, n)
+			}
+		}
+		if first := bytes.Index(c, []byte("(Item ")); first < 0 || bytes.Index(c, []byte("(Description)")) > first {
+			t.Errorf("page %d draws no body row, or one before the header", i+1)
+		}
+		for n := 1; n <= 80; n++ {
+			seen[fmt.Sprintf("Item %d", n)] += shown(c, fmt.Sprintf("Item %d", n))
+		}
+	}
+	for text, n := range seen {
+		if n != 1 {
+			t.Errorf("body row %q drawn %d times, want once", text, n)
+		}
+	}
+}
+
+func TestRenderFormFieldNames(t *testing.T) {
+	cell := func(f domain.FormField) domain.Cell {
+		return domain.Cell{Props: testProps, FormField: &f}
+	}
+	doc := &domain.Document{
+		Config: domain.Config{Page: string(domain.PageSizeA4)},
+		Title: domain.Title{Table: &domain.Table{MaxColumns: 1, ColumnWidths: []float64{1}, Rows: []domain.Row{
+			{Cells: []domain.Cell{cell(domain.FormField{Type: domain.FormFieldText, Name: "form_id", Value: "F-1"})}},
+		}}},
+		Tables: []domain.Table{
+			{MaxColumns: 2, ColumnWidths: []float64{1, 1}, Rows: []domain.Row{
+				{Cells: []domain.Cell{
+					cell(domain.FormField{Type: domain.FormFieldText, Name: "first_name", Value: "Michael"}),
+					cell(domain.FormField{Type: domain.FormFieldCheckbox, Name: "smoker"}),
+				}},
+				{Cells: []domain.Cell{
+					cell(domain.FormField{Type: domain.FormFieldRadio, Name: "gender_male", GroupName: "gender", Value: "male", Checked: true}),
+					cell(domain.FormField{Type: domain.FormFieldRadio, Name: "gender_female", GroupName: "gender", Value: "female"}),
+				}},
+				{Height: 40, Cells: []domain.Cell{
+					cell(domain.FormField{Type: domain.FormFieldDropdown, Name: "state", Value: "CA", Options: []string{"CA", "NY"}}),
+					cell(domain.FormField{Type: domain.FormFieldListbox, Name: "allergies", Options: []string{"Latex", "Pollen"}, MultiSelect: true, Selected: []string{"Pollen"}}),
+				}},
+				{Height: 40, Cells: []domain.Cell{
+					cell(domain.FormField{Type: domain.FormFieldMultiline, Name: "notes"}),
+					cell(domain.FormField{Type: domain.FormFieldSignature, Name: "approval"}),
+				}},
+			}},
+		},
+	}
+
+	got := fieldNames(t, render(t, doc))
+	want := []string{"form_id", "first_name", "smoker", "gender", "state", "allergies", "notes", "approval"}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("AcroForm fields\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,235 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// styledRun is a text run resolved against the style of its cell.
+type styledRun struct {
+	text string
+	st   cellStyle
+	link string
+}
+
+// span is the part of a wrapped line that belongs to one run.
+type span struct {
+	run   int
+	text  string
+	width float64
+}
+
+// richLine is a wrapped line of runs. Its height is the leading of the
+// largest run on it.
+type richLine struct {
+	spans   []span
+	width   float64
+	size    float64
+	justify bool
+}
+
+// fragment is the part of a word that belongs to one run.
+type fragment struct {
+	run  int
+	text string
+}
+
+// richWord is a word that may span several runs, such as "(**bold**)".
+// space is the run of the space before it, or -1 without one.
+type richWord struct {
+	frags []fragment
+	space int
+}
+
+// resolveRuns applies the run styles over the cell style. Alignment and
+// borders stay those of the cell.
+func resolveRuns(runs []domain.TextRun, cell cellStyle) []styledRun {
+	out := make([]styledRun, len(runs))
+	for i, r := range runs {
+		st := cell
+		if r.Style != nil {
+			st.size = float64(r.Style.Size)
+			if st.size <= 0 {
+				// As in the props string the run is sent with.
+				st.size = float64(domain.DefaultStyle().Size)
+			}
+			st.bold, st.italic, st.underline = r.Style.Bold, r.Style.Italic, r.Style.Underline
+			if c := colorOf(r.Style.TextColor); c != nil {
+				st.textColor = c
+			}
+		}
+		out[i] = styledRun{text: r.Text, st: st, link: r.Link}
+	}
+	return out
+}
+
+// splitRuns breaks runs into the words of each newline-separated paragraph.
+func splitRuns(runs []styledRun) [][]richWord {
+	paras := [][]richWord{nil}
+	var word richWord
+	space := -1
+	flush := func() {
+		if len(word.frags) > 0 {
+			word.space = space
+			last := len(paras) - 1
+			paras[last] = append(paras[last], word)
+			space = -1
+		}
+		word = richWord{}
+	}
+	for i, r := range runs {
+		for _, c := range r.text {
+			switch c {
+			case '\n':
+				flush()
+				space = -1
+				paras = append(paras, nil)
+			case ' ', '\t', '\r':
+				flush()
+				if len(paras[len(paras)-1]) > 0 {
+					space = i
+				}
+			default:
+				if n := len(word.frags); n > 0 && word.frags[n-1].run == i {
+					word.frags[n-1].text += string(c)
+				} else {
+					word.frags = append(word.frags, fragment{run: i, text: string(c)})
+				}
+			}
+		}
+	}
+	flush()
+	return paras
+}
+
+// wrapRuns breaks runs into lines no wider than maxWidth. Like wrapText,
+// newlines start a new line and long words are kept whole.
+func wrapRuns(runs []styledRun, base cellStyle, maxWidth float64) []richLine {
+	width := func(run int, text string) float64 {
+		st := runs[run].st
+		return textWidth(encodeWinAnsi(text), st.face(), st.size)
+	}
+	var lines []richLine
+	for _, para := range splitRuns(runs) {
+		first := len(lines)
+		line := richLine{size: base.size}
+		for _, w := range para {
+			ww := 0.0
+			for _, f := range w.frags {
+				ww += width(f.run, f.text)
+			}
+			sw := 0.0
+			if w.space >= 0 && len(line.spans) > 0 {
+				sw = width(w.space, " ")
+			}
+			if len(line.spans) > 0 && line.width+sw+ww > maxWidth {
+				lines = append(lines, line)
+				line, sw = richLine{size: base.size}, 0
+			}
+			if sw > 0 {
+				line.add(w.space, " ", sw)
+			}
+			for _, f := range w.frags {
+				line.add(f.run, f.text, width(f.run, f.text))
+				line.size = max(line.size, runs[f.run].st.size)
+			}
+		}
+		lines = append(lines, line)
+		if base.alignment == domain.AlignJustify {
+			for i := first; i < len(lines)-1; i++ {
+				lines[i].justify = true
+			}
+		}
+	}
+	return lines
+}
+
+// add appends text of a run to the line, extending its last span when it
+// belongs to the same run.
+func (l *richLine) add(run int, text string, width float64) {
+	if n := len(l.spans); n > 0 && l.spans[n-1].run == run {
+		l.spans[n-1].text += text
+		l.spans[n-1].width += width
+	} else {
+		l.spans = append(l.spans, span{run: run, text: text, width: width})
+	}
+	l.width += width
+}
+
+// leading returns the height of the line.
+func (l richLine) leading() float64 {
+	return l.size * 1.2
+}
+
+// runsHeight returns the height of runs wrapped to the given width.
+func runsHeight(runs []domain.TextRun, st cellStyle, width float64) float64 {
+	total := 0.0
+	for _, line := range wrapRuns(resolveRuns(runs, st), st, width) {
+		total += line.leading()
+	}
+	return total
+}
+
+// drawRuns draws wrapped, aligned runs vertically centered in a box, with a
+// link annotation over every linked span.
+func (l *layout) drawRuns(x, y, w, h float64, st cellStyle, runs []domain.TextRun) {
+	resolved := resolveRuns(runs, st)
+	lines := wrapRuns(resolved, st, w-2*cellPadding)
+	total := 0.0
+	for _, line := range lines {
+		total += line.leading()
+	}
+	top := y + (h-total)/2
+	for _, line := range lines {
+		if !l.clipped(top, top+line.leading()) {
+			l.drawRichLine(x+cellPadding, top, w-2*cellPadding, st, line, resolved)
+		}
+		top += line.leading()
+	}
+}
+
+// drawRichLine draws the spans of a line whose top is at y.
+func (l *layout) drawRichLine(x, y, w float64, st cellStyle, line richLine, runs []styledRun) {
+	c := &l.cur.canvas
+	wordSpacing := 0.0
+	if spaces := countSpaces(line); line.justify && spaces > 0 && line.width < w {
+		wordSpacing = (w - line.width) / float64(spaces)
+	}
+	lx := alignX(x, w, line.width+wordSpacing*float64(countSpaces(line)), st.alignment)
+	baseline := l.pdfY(y + line.size*0.86)
+	for _, sp := range line.spans {
+		rs := runs[sp.run].st
+		encoded := encodeWinAnsi(sp.text)
+		sw := sp.width + wordSpacing*float64(strings.Count(sp.text, " "))
+		if rs.textColor != nil {
+			c.save()
+			c.fillColor(*rs.textColor)
+			c.strokeColor(*rs.textColor)
+		}
+		if wordSpacing > 0 {
+			c.justifiedText(lx, baseline, rs.face(), rs.size, wordSpacing, encoded)
+		} else {
+			c.text(lx, baseline, rs.face(), rs.size, encoded)
+		}
+		if rs.underline {
+			c.line(lx, baseline-rs.size*0.1, lx+sw, baseline-rs.size*0.1, rs.size*0.05)
+		}
+		if rs.textColor != nil {
+			c.restore()
+		}
+		if link := runs[sp.run].link; link != "" && strings.TrimSpace(sp.text) != "" {
+			l.addLink(lx, baseline-rs.size*0.22, lx+sw, baseline+rs.size*0.86, link)
+		}
+		lx += sw
+	}
+}
+
+// countSpaces returns the number of spaces on a line.
+func countSpaces(line richLine) int {
+	n := 0
+	for _, sp := range line.spans {
+		n += strings.Count(sp.text, " ")
+	}
+	return n
+}
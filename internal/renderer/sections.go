@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// hasHeader reports whether the given 1-based page shows a header.
+func (l *layout) hasHeader(page int) bool {
+	h := l.doc.Header
+	return h != nil && h.HasSlots() && h.ShownOn(page)
+}
+
+// hasFooter reports whether the given 1-based page shows a footer.
+func (l *layout) hasFooter(page int) bool {
+	f := l.doc.Footer
+	return (f.Text != "" || f.HasSlots()) && f.ShownOn(page)
+}
+
+// drawPageSections draws the header and footer of every page once the page
+// count is known, resolving the page tokens.
+func (l *layout) drawPageSections(date time.Time) {
+	info := domain.PageInfo{Pages: len(l.pages), Date: date, Title: l.doc.Title.Text}
+	for i, p := range l.pages {
+		info.Page = i + 1
+		l.cur = p
+		if l.hasHeader(info.Page) {
+			st := parseStyle(l.doc.Header.Font)
+			baseline := l.pdfY(l.margin.Top.Points() + st.size*0.86)
+			l.drawSlots(baseline, st, l.doc.Header.PageSlots, info)
+		}
+		if l.hasFooter(info.Page) {
+			footer := l.doc.Footer
+			st := parseStyle(footer.Font)
+			baseline := l.pdfY(l.height - l.margin.Bottom.Points() - st.size*0.3)
+			if footer.Text != "" {
+				l.drawSlot(baseline, st, st.alignment, domain.ExpandTokens(footer.Text, info))
+			}
+			l.drawSlots(baseline, st, footer.PageSlots, info)
+		}
+	}
+}
+
+// drawSlots draws the left, center and right texts of a header or footer.
+func (l *layout) drawSlots(baseline float64, st cellStyle, slots domain.PageSlots, info domain.PageInfo) {
+	l.drawSlot(baseline, st, domain.AlignLeft, domain.ExpandTokens(slots.Left, info))
+	l.drawSlot(baseline, st, domain.AlignCenter, domain.ExpandTokens(slots.Center, info))
+	l.drawSlot(baseline, st, domain.AlignRight, domain.ExpandTokens(slots.Right, info))
+}
+
+// drawSlot draws one line of text aligned within the content width.
+func (l *layout) drawSlot(baseline float64, st cellStyle, align domain.Alignment, text string) {
+	if text == "" {
+		return
+	}
+	encoded := encodeWinAnsi(text)
+	x := alignX(l.left(), l.contentWidth(), textWidth(encoded, st.face(), st.size), align)
+	l.cur.canvas.text(x, baseline, st.face(), st.size, encoded)
+}
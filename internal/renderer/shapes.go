@@ -0,0 +1,118 @@
+package renderer
+
+import (
+	"math"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// defaultStrokeWidth is the outline width of shapes without a StrokeWidth.
+const defaultStrokeWidth = 1
+
+// drawShapes draws the positioned shapes of the document beneath the content
+// of their pages.
+func (l *layout) drawShapes() {
+	if len(l.doc.Shapes) == 0 {
+		return
+	}
+	for i, p := range l.pages {
+		var under canvas
+		for _, s := range l.doc.Shapes {
+			if s.ShownOn(i + 1) {
+				l.drawShape(&under, s)
+			}
+		}
+		p.canvas.prepend(&under)
+	}
+}
+
+// drawShape strokes and fills a shape on the canvas.
+func (l *layout) drawShape(c *canvas, s domain.Shape) {
+	x, y := s.X.Points(), s.Y.Points()
+	w, h := s.Width.Points(), s.Height.Points()
+	stroke, fill := colorOf(s.Stroke), colorOf(s.Fill)
+	if s.Type == domain.ShapeLine {
+		fill = nil
+	}
+	if stroke == nil && fill == nil {
+		stroke = &rgb{}
+	}
+
+	c.save()
+	defer c.restore()
+	if stroke != nil {
+		width := s.StrokeWidth.Points()
+		if width <= 0 {
+			width = defaultStrokeWidth
+		}
+		c.op(width, "w")
+		c.strokeColor(*stroke)
+	}
+	if fill != nil {
+		c.fillColor(*fill)
+	}
+	switch s.Type {
+	case domain.ShapeLine:
+		c.op(x, l.pdfY(y), "m", x+w, l.pdfY(y+h), "l")
+	case domain.ShapeRect:
+		c.op(x, l.pdfY(y+h), w, h, "re")
+	case domain.ShapeRoundRect:
+		c.roundRect(x, l.pdfY(y+h), w, h, s.Radius.Points())
+	case domain.ShapeEllipse:
+		c.ellipse(x+w/2, l.pdfY(y+h/2), w/2, h/2)
+	default:
+		c.op("n")
+		return
+	}
+	switch {
+	case stroke != nil && fill != nil:
+		c.op("B")
+	case fill != nil:
+		c.op("f")
+	default:
+		c.op("S")
+	}
+}
+
+// drawTextBoxes draws the positioned text boxes of the document above the
+// content of their pages, expanding the header and footer tokens.
+func (l *layout) drawTextBoxes(date time.Time) {
+	if len(l.doc.TextBoxes) == 0 {
+		return
+	}
+	info := domain.PageInfo{Pages: len(l.pages), Date: date, Title: l.doc.Title.Text}
+	for i, p := range l.pages {
+		info.Page = i + 1
+		l.cur = p
+		for _, b := range l.doc.TextBoxes {
+			if b.ShownOn(info.Page) {
+				l.drawTextBox(b, info)
+			}
+		}
+	}
+}
+
+// drawTextBox draws a text box as a cell, sizing it to its text when its
+// width or height is zero.
+func (l *layout) drawTextBox(b domain.TextBox, info domain.PageInfo) {
+	cell := domain.Cell{
+		Props:       b.Props,
+		Text:        domain.ExpandTokens(b.Text, info),
+		BgColor:     b.BgColor,
+		TextColor:   b.TextColor,
+		BorderColor: b.BorderColor,
+	}
+	w, h := b.Width.Points(), b.Height.Points()
+	if w <= 0 {
+		st := cellStyleOf(cell)
+		for _, line := range wrapText(cell.Text, st.face(), st.size, math.Inf(1)) {
+			w = max(w, textWidth(encodeWinAnsi(line), st.face(), st.size))
+		}
+		w += 2 * cellPadding
+	}
+	if h <= 0 {
+		h = l.cellHeight(cell, w)
+	}
+	l.drawCell(b.X.Points(), b.Y.Points(), w, h, cell)
+}
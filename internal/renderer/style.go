@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// cellStyle is a domain.Style resolved to the units used while drawing.
+type cellStyle struct {
+	size      float64
+	bold      bool
+	italic    bool
+	underline bool
+	alignment domain.Alignment
+	borders   [4]float64 // top, right, bottom, left
+	// Colors are nil when unset or unparseable.
+	background  *rgb
+	textColor   *rgb
+	borderColor *rgb
+}
+
+// rgb is a color with components between 0 and 1.
+type rgb [3]float64
+
+// colorOf converts a domain color, returning nil when it is empty or invalid.
+func colorOf(c domain.Color) *rgb {
+	if c == "" {
+		return nil
+	}
+	r, g, b, err := c.RGB()
+	if err != nil {
+		return nil
+	}
+	return &rgb{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+// parseStyle decodes a props string. Empty or malformed props fall back to
+// domain.DefaultStyle so that rendering never fails on cosmetic input;
+// Document.Validate reports them instead.
+func parseStyle(props string) cellStyle {
+	s, err := domain.ParseProps(props)
+	if err != nil {
+		s = domain.DefaultStyle()
+	}
+	return cellStyle{
+		size:      float64(s.Size),
+		bold:      s.Bold,
+		italic:    s.Italic,
+		underline: s.Underline,
+		alignment: s.Alignment,
+		borders: [4]float64{
+			float64(s.Borders.Top), float64(s.Borders.Right),
+			float64(s.Borders.Bottom), float64(s.Borders.Left),
+		},
+	}
+}
+
+// cellStyleOf decodes the props and colors of a cell.
+func cellStyleOf(cell domain.Cell) cellStyle {
+	st := parseStyle(cell.Props)
+	st.background = colorOf(cell.BgColor)
+	st.textColor = colorOf(cell.TextColor)
+	st.borderColor = colorOf(cell.BorderColor)
+	return st
+}
+
+// face returns the font face for the style.
+func (s cellStyle) face() fontFace {
+	return faceFor(s.bold, s.italic)
+}
+
+// leading returns the line height for the style.
+func (s cellStyle) leading() float64 {
+	return s.size * 1.2
+}
@@ -0,0 +1,494 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+)
+
+// breakTolerance is the rounding error, in points, allowed when placing page
+// breaks inside table rows.
+const breakTolerance = 0.01
+
+// Minimum sizes, in points, for cells that carry form fields.
+const (
+	minFieldHeight     = 16
+	minSignatureHeight = 36
+	toggleFieldSize    = 10
+)
+
+// renderTitle draws the title table, or the title text when no table is set.
+// keepNext is the height that must follow it on the same page.
+func (l *layout) renderTitle(keepNext float64) {
+	title := l.doc.Title
+	if title.Table != nil {
+		l.renderTable(*title.Table, keepNext)
+		return
+	}
+	if title.Text == "" {
+		return
+	}
+	l.renderTable(domain.Table{
+		MaxColumns:   1,
+		ColumnWidths: []float64{1},
+		Rows:         []domain.Row{{Cells: []domain.Cell{{Props: title.Props, Text: title.Text}}}},
+	}, keepNext)
+}
+
+// tableGeometry holds the measured grid of a table.
+type tableGeometry struct {
+	widths     []float64
+	heights    []float64
+	placements [][]domain.Placement
+	header     int // number of header rows
+}
+
+// measureTable resolves the column widths, cell placements and row heights.
+func (l *layout) measureTable(t domain.Table) (domain.Table, tableGeometry) {
+	g := tableGeometry{widths: l.columnWidths(t)}
+	if t.MaxColumns <= 0 {
+		t.MaxColumns = len(g.widths)
+	}
+	g.placements, _ = t.Place()
+	g.heights = l.rowHeights(t, g.placements, g.widths)
+	g.header = min(max(t.HeaderRows, 0), len(t.Rows))
+	return t, g
+}
+
+// leadHeight returns the height of the header rows and the first group of
+// body rows, the least of a table that may start a page.
+func (l *layout) leadHeight(t domain.Table) float64 {
+	t, g := l.measureTable(t)
+	if g.header == len(t.Rows) {
+		return sum(g.heights)
+	}
+	return sum(g.heights[:spanGroupEnd(g.placements, g.header)])
+}
+
+// tableFlow tracks a table being laid out across pages.
+type tableFlow struct {
+	t       domain.Table
+	g       tableGeometry
+	repeat  int     // header rows drawn again on continuation pages
+	bodyTop float64 // position below the header rows on the current page
+}
+
+// renderTable draws a table row by row. Rows joined by a row-spanning cell
+// form a group. A group that does not fit in the space left on the page is
+// split at the bottom of the page, or moved whole to the next page when the
+// table keeps its rows together; groups taller than a page are always split.
+// The header rows are drawn again at the top of every page the table
+// continues on, unless they take more than half the page. keepNext is the
+// height that must follow the table on the same page as its last group of
+// rows.
+func (l *layout) renderTable(t domain.Table, keepNext float64) {
+	t, g := l.measureTable(t)
+	f := &tableFlow{t: t, g: g}
+	need := func(start, end int) float64 {
+		h := sum(g.heights[start:end])
+		if end == len(t.Rows) {
+			h += keepNext
+		}
+		return h
+	}
+
+	l.ensureSpace(0)
+	f.bodyTop = l.top()
+	if g.header > 0 {
+		first := g.header
+		if first < len(t.Rows) {
+			first = spanGroupEnd(g.placements, first)
+		}
+		headerHeight := sum(g.heights[:g.header])
+		l.ensureSpace(headerHeight + need(g.header, first))
+		l.drawGroup(f, 0, g.header)
+		f.bodyTop = l.y
+		if headerHeight <= (l.bottom()-l.top())/2 {
+			f.repeat = g.header
+		}
+	}
+	for start := g.header; start < len(t.Rows); {
+		end := spanGroupEnd(g.placements, start)
+		if l.y+need(start, end) > l.bottom() && l.y > f.bodyTop {
+			split := !t.KeepRowsTogether && l.y+sum(g.heights[start:end]) > l.bottom() &&
+				breakBefore(l.rowKeeps(t, g, start, end), 0, l.bottom()-l.y) > 0
+			if !split {
+				l.continueTable(f)
+			}
+		}
+		l.drawGroup(f, start, end)
+		start = end
+	}
+}
+
+// continueTable starts a new page and draws the repeated header rows on it.
+func (l *layout) continueTable(f *tableFlow) {
+	l.newPage()
+	l.drawRows(f.t, f.g, 0, f.repeat, true)
+	f.bodyTop = l.y
+}
+
+// drawGroup draws rows [start, end) at the current position. Rows taller
+// than the space left on the page are split across as many pages as they
+// need. Pages break between lines of text and around images and form
+// fields, and only cut through one of them when it is taller than a page.
+func (l *layout) drawGroup(f *tableFlow, start, end int) {
+	total := sum(f.g.heights[start:end])
+	if l.y+total <= l.bottom() {
+		l.drawRows(f.t, f.g, start, end, false)
+		return
+	}
+	keeps := l.rowKeeps(f.t, f.g, start, end)
+	for from := 0.0; ; {
+		limit := from + l.bottom() - l.y
+		if limit <= from {
+			l.fail(fmt.Errorf("%w: no room for table rows between the page margins", domain.ErrInvalidConfig))
+			return
+		}
+		if limit >= total {
+			l.drawRowsPart(f.t, f.g, start, end, from, total)
+			return
+		}
+		to := breakBefore(keeps, from, limit)
+		if to == from {
+			if l.y > f.bodyTop {
+				l.continueTable(f)
+				continue
+			}
+			to = limit
+		}
+		l.drawRowsPart(f.t, f.g, start, end, from, to)
+		from = to
+		l.continueTable(f)
+	}
+}
+
+// drawRows draws rows [start, end) at the current position. Repeated header
+// rows are drawn without their form fields, which must stay unique. The
+// anchor of the table is placed at its first row.
+func (l *layout) drawRows(t domain.Table, g tableGeometry, start, end int, repeat bool) {
+	if start == 0 && !repeat && t.Anchor != "" {
+		l.addAnchor(t.Anchor)
+	}
+	for r := start; r < end; r++ {
+		for i, cell := range t.Rows[r].Cells {
+			p := g.placements[r][i]
+			if p.ColSpan == 0 {
+				continue
+			}
+			if repeat {
+				cell.FormField = nil
+			}
+			x := l.left() + sum(g.widths[:p.Column])
+			w := sum(g.widths[p.Column : p.Column+p.ColSpan])
+			h := sum(g.heights[r:min(r+p.RowSpan, len(g.heights), end)])
+			l.drawCell(x, l.y, w, h, cell)
+		}
+		l.y += g.heights[r]
+	}
+}
+
+// drawRowsPart draws the part of rows [start, end) between from and to,
+// measured from the top of the rows, at the current position. Cells are
+// clipped to the part and so are their links. A form field is drawn with
+// the part that holds the top of its cell.
+func (l *layout) drawRowsPart(t domain.Table, g tableGeometry, start, end int, from, to float64) {
+	if start == 0 && from == 0 && t.Anchor != "" {
+		l.addAnchor(t.Anchor)
+	}
+	top := l.y - from
+	clipTop, clipBottom := l.y, l.y+to-from
+	if from == 0 {
+		clipTop -= cellPadding // keeps the top borders whole
+	}
+	if to == sum(g.heights[start:end]) {
+		clipBottom += cellPadding
+	}
+	c := &l.cur.canvas
+	c.save()
+	c.clip(0, l.pdfY(clipBottom), l.width, clipBottom-clipTop)
+	l.clip = &[2]float64{l.y, l.y + to - from}
+	for r := start; r < end; r++ {
+		y := sum(g.heights[start:r])
+		for i, cell := range t.Rows[r].Cells {
+			p := g.placements[r][i]
+			if p.ColSpan == 0 {
+				continue
+			}
+			h := sum(g.heights[r:min(r+p.RowSpan, len(g.heights), end)])
+			if y+h <= from || y >= to {
+				continue
+			}
+			if y < from && cell.FormField != nil {
+				cell.FormField, cell.Text, cell.Runs = nil, "", nil
+			}
+			x := l.left() + sum(g.widths[:p.Column])
+			w := sum(g.widths[p.Column : p.Column+p.ColSpan])
+			l.drawCell(x, top+y, w, h, cell)
+		}
+	}
+	l.clip = nil
+	c.restore()
+	l.y += to - from
+}
+
+// rowKeeps returns the spans of rows [start, end), measured from their top,
+// that a page break must not cut through: the lines of text, the images and
+// the form fields of their cells. Lines are kept with the padding that
+// separates them from the cell border.
+func (l *layout) rowKeeps(t domain.Table, g tableGeometry, start, end int) [][2]float64 {
+	var keeps [][2]float64
+	// lines keeps consecutive lines starting at y in a cell from top to
+	// bottom.
+	lines := func(top, bottom, y float64, leadings ...float64) {
+		first := len(keeps)
+		for _, h := range leadings {
+			keeps = append(keeps, [2]float64{y, y + h})
+			y += h
+		}
+		if len(keeps) > first {
+			if keeps[first][0]-top <= cellPadding+breakTolerance {
+				keeps[first][0] = top
+			}
+			if bottom-keeps[len(keeps)-1][1] <= cellPadding+breakTolerance {
+				keeps[len(keeps)-1][1] = bottom
+			}
+		}
+	}
+	for r := start; r < end; r++ {
+		top := sum(g.heights[start:r])
+		for i, cell := range t.Rows[r].Cells {
+			p := g.placements[r][i]
+			if p.ColSpan == 0 {
+				continue
+			}
+			w := sum(g.widths[p.Column:p.Column+p.ColSpan]) - 2*cellPadding
+			h := sum(g.heights[r:min(r+p.RowSpan, len(g.heights), end)])
+			st := cellStyleOf(cell)
+			switch {
+			case cell.FormField != nil:
+				keeps = append(keeps, [2]float64{top, top + h})
+			case cell.Image != nil:
+				iw, ih, err := l.imageSize(*cell.Image)
+				if err != nil {
+					continue // reported when the cell is drawn
+				}
+				_, fh := fitBox(iw, ih, w, h-2*cellPadding)
+				lines(top, top+h, top+(h-fh)/2, fh)
+			case len(cell.Runs) > 0:
+				var leadings []float64
+				for _, line := range wrapRuns(resolveRuns(cell.Runs, st), st, w) {
+					leadings = append(leadings, line.leading())
+				}
+				lines(top, top+h, top+(h-sum(leadings))/2, leadings...)
+			case cell.Text != "":
+				leadings := make([]float64, len(textLines(cell.Text, st, w)))
+				for k := range leadings {
+					leadings[k] = st.leading()
+				}
+				lines(top, top+h, top+(h-sum(leadings))/2, leadings...)
+			}
+		}
+	}
+	return keeps
+}
+
+// breakBefore returns the lowest position after from and at most limit that
+// cuts through none of keeps, or from when there is none.
+func breakBefore(keeps [][2]float64, from, limit float64) float64 {
+	cut := limit
+	for moved := true; moved && cut > from; {
+		moved = false
+		for _, k := range keeps {
+			if k[0]+breakTolerance < cut && cut < k[1]-breakTolerance {
+				cut, moved = k[0], true
+			}
+		}
+	}
+	return max(cut, from)
+}
+
+// spanGroupEnd returns the index just past the last row reached by row-spanning
+// cells that start in or are pulled into the group beginning at start.
+func spanGroupEnd(placements [][]domain.Placement, start int) int {
+	end := start + 1
+	for r := start; r < end && r < len(placements); r++ {
+		for _, p := range placements[r] {
+			end = max(end, r+p.RowSpan)
+		}
+	}
+	return min(end, len(placements))
+}
+
+// rowHeights returns the height of every row. Rows without an explicit height
+// fit their tallest single-row cell; a row-spanning cell that needs more room
+// than its rows provide stretches the last of them.
+func (l *layout) rowHeights(t domain.Table, placements [][]domain.Placement, widths []float64) []float64 {
+	heights := make([]float64, len(t.Rows))
+	for r, row := range t.Rows {
+		if row.Height > 0 {
+			heights[r] = float64(row.Height)
+			continue
+		}
+		for i, cell := range row.Cells {
+			if p := placements[r][i]; p.ColSpan > 0 && p.RowSpan == 1 {
+				heights[r] = math.Max(heights[r], l.cellHeight(cell, sum(widths[p.Column:p.Column+p.ColSpan])))
+			}
+		}
+	}
+
+	for r, row := range t.Rows {
+		for i, cell := range row.Cells {
+			p := placements[r][i]
+			if p.ColSpan == 0 || p.RowSpan == 1 {
+				continue
+			}
+			last := min(r+p.RowSpan, len(heights)) - 1
+			need := l.cellHeight(cell, sum(widths[p.Column:p.Column+p.ColSpan])) - sum(heights[r:last+1])
+			if need <= 0 {
+				continue
+			}
+			for k := last; k >= r; k-- {
+				if t.Rows[k].Height <= 0 {
+					heights[k] += need
+					break
+				}
+			}
+		}
+	}
+	return heights
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// columnWidths resolves the relative column widths of a table to points.
+// Missing or invalid widths fall back to equal columns.
+func (l *layout) columnWidths(t domain.Table) []float64 {
+	n := t.MaxColumns
+	if n <= 0 {
+		for _, row := range t.Rows {
+			if len(row.Cells) > n {
+				n = len(row.Cells)
+			}
+		}
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	weights := make([]float64, n)
+	total := 0.0
+	valid := len(t.ColumnWidths) == n
+	for i := range weights {
+		weights[i] = 1
+		if valid {
+			if t.ColumnWidths[i] <= 0 {
+				valid = false
+				continue
+			}
+			weights[i] = t.ColumnWidths[i]
+		}
+	}
+	if !valid {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	for _, w := range weights {
+		total += w
+	}
+
+	widths := make([]float64, n)
+	for i, w := range weights {
+		widths[i] = l.contentWidth() * w / total
+	}
+	return widths
+}
+
+// cellHeight returns the natural height of a cell rendered at the given width.
+// An image cell is as tall as its image scaled down to the cell width.
+func (l *layout) cellHeight(cell domain.Cell, width float64) float64 {
+	st := cellStyleOf(cell)
+	if cell.Image != nil {
+		w, h, err := l.imageSize(*cell.Image)
+		if err != nil {
+			l.fail(err)
+			return 2 * cellPadding
+		}
+		_, fh := fitBox(w, h, width-2*cellPadding, 0)
+		return fh + 2*cellPadding
+	}
+	var h float64
+	if len(cell.Runs) > 0 && cell.FormField == nil {
+		h = runsHeight(cell.Runs, st, width-2*cellPadding) + 2*cellPadding
+	} else {
+		lines := len(wrapText(cell.Text, st.face(), st.size, width-2*cellPadding))
+		h = float64(lines)*st.leading() + 2*cellPadding
+	}
+	if f := cell.FormField; f != nil {
+		fieldHeight := float64(minFieldLines(*f))*st.leading() + 2*cellPadding + 4
+		if f.Type == domain.FormFieldSignature {
+			fieldHeight = minSignatureHeight
+		}
+		h = math.Max(h, math.Max(minFieldHeight, fieldHeight))
+	}
+	return h
+}
+
+// drawCell draws the borders, text and form field of a cell whose top-left
+// corner is at (x, y) in top-down coordinates.
+func (l *layout) drawCell(x, y, w, h float64, cell domain.Cell) {
+	st := cellStyleOf(cell)
+	c := &l.cur.canvas
+	if st.background != nil {
+		c.fillRect(x, l.pdfY(y+h), w, h, *st.background)
+	}
+	if st.borderColor != nil {
+		c.save()
+		c.strokeColor(*st.borderColor)
+		drawBorders(c, x, l.pdfY(y), x+w, l.pdfY(y+h), st.borders)
+		c.restore()
+	} else {
+		drawBorders(c, x, l.pdfY(y), x+w, l.pdfY(y+h), st.borders)
+	}
+
+	if cell.Link != "" {
+		l.addLink(x, l.pdfY(y+h), x+w, l.pdfY(y), cell.Link)
+	}
+	if cell.Image != nil {
+		l.drawCellImage(x, y, w, h, st, *cell.Image)
+		return
+	}
+	if f := cell.FormField; f != nil {
+		l.form.addField(l.cur, x, y, w, h, st, *f)
+		if drawsOwnText(*f) {
+			return
+		}
+	}
+	if len(cell.Runs) > 0 {
+		l.drawRuns(x, y, w, h, st, cell.Runs)
+		return
+	}
+	l.drawText(x, y, w, h, st, cell.Text)
+}
+
+// drawText draws wrapped, aligned text vertically centered in a box.
+func (l *layout) drawText(x, y, w, h float64, st cellStyle, text string) {
+	if text == "" {
+		return
+	}
+	lines := textLines(text, st, w-2*cellPadding)
+	blockTop := y + (h-float64(len(lines))*st.leading())/2
+	for i, line := range lines {
+		if top := blockTop + float64(i)*st.leading(); !l.clipped(top, top+st.leading()) {
+			l.drawLine(x+cellPadding, top, w-2*cellPadding, st, line)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package renderer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Table of contents layout, in points.
+const (
+	contentsIndent      = 12 // per entry level
+	contentsNumberWidth = 30 // kept free for page numbers
+	contentsLeaderGap   = 4
+)
+
+// contentsEntry is an entry of a table of contents, pointing to the n-th
+// heading of the document or to a bookmarked table anchor.
+type contentsEntry struct {
+	level   int
+	text    string
+	heading int // -1 for a table anchor
+	anchor  string
+}
+
+// contentsLine is an entry drawn during layout. Its page number, leader and
+// link are added by drawContents once every page is known.
+type contentsLine struct {
+	page  *page
+	top   float64 // top of the first line of the entry
+	y     float64 // top of the last line
+	x     float64 // start of the entry text
+	end   float64 // end of the text on the last line
+	st    cellStyle
+	entry contentsEntry
+}
+
+// contentsEntries collects the headings up to depth and the bookmarked
+// tables of the body in document order.
+func (l *layout) contentsEntries(depth int) []contentsEntry {
+	if depth <= 0 {
+		depth = domain.MaxHeadingLevel
+	}
+	type mark struct {
+		title string
+		level int
+	}
+	marks := make(map[string]mark)
+	var collect func(bookmarks []domain.Bookmark, level int)
+	collect = func(bookmarks []domain.Bookmark, level int) {
+		for _, b := range bookmarks {
+			if _, ok := marks[b.Anchor]; !ok {
+				marks[b.Anchor] = mark{b.Title, level}
+			}
+			collect(b.Children, level+1)
+		}
+	}
+	collect(l.doc.Bookmarks, 1)
+
+	var entries []contentsEntry
+	headings := 0
+	var walk func(blocks []domain.Block)
+	walk = func(blocks []domain.Block) {
+		for _, b := range blocks {
+			switch b := b.(type) {
+			case domain.Heading:
+				level := min(max(b.Level, domain.MinHeadingLevel), domain.MaxHeadingLevel)
+				if level <= depth {
+					entries = append(entries, contentsEntry{level: level, text: b.Text, heading: headings})
+				}
+				headings++
+			case domain.Table:
+				if m, ok := marks[b.Anchor]; ok && b.Anchor != "" && m.level <= depth {
+					entries = append(entries, contentsEntry{level: m.level, text: m.title, heading: -1, anchor: b.Anchor})
+				}
+			case domain.Group:
+				walk(b.Blocks)
+			}
+		}
+	}
+	walk(l.body)
+	return entries
+}
+
+// contentsStyles resolves the title and entry styles of a table of
+// contents. Entries are always left aligned.
+func contentsStyles(toc domain.TableOfContents) (title, entry cellStyle) {
+	title = headingStyle(domain.Heading{Level: domain.MinHeadingLevel})
+	if toc.TitleProps != "" {
+		title = parseStyle(toc.TitleProps)
+		title.borders = [4]float64{}
+	}
+	entry = parseStyle(toc.Props)
+	entry.borders = [4]float64{}
+	entry.alignment = domain.AlignLeft
+	return title, entry
+}
+
+// contentsLayout returns the position and wrapped lines of an entry.
+func (l *layout) contentsLayout(e contentsEntry, st cellStyle) (x, w float64, lines []textLine) {
+	indent := float64(e.level-1) * contentsIndent
+	x = l.left() + indent
+	w = l.contentWidth() - indent - contentsNumberWidth
+	return x, w, textLines(e.text, st, w)
+}
+
+// renderContents draws the title and entry texts of a table of contents.
+// Entries are kept whole on a page.
+func (l *layout) renderContents(toc domain.TableOfContents, keepNext float64) {
+	titleStyle, st := contentsStyles(toc)
+	entries := l.contentsEntries(toc.Depth)
+	if toc.Title != "" {
+		first := keepNext
+		if len(entries) > 0 {
+			_, _, lines := l.contentsLayout(entries[0], st)
+			first = float64(len(lines)) * st.leading()
+		}
+		l.drawHeading(titleStyle, toc.Title, first)
+	}
+	for i, e := range entries {
+		x, w, lines := l.contentsLayout(e, st)
+		need := float64(len(lines)) * st.leading()
+		if i == len(entries)-1 {
+			need += keepNext
+		}
+		l.ensureSpace(need)
+		line := contentsLine{page: l.cur, top: l.y, x: x, st: st, entry: e}
+		for j, text := range lines {
+			l.drawLine(x, l.y, w, st, text)
+			if j == len(lines)-1 {
+				line.y = l.y
+				line.end = x + textWidth(encodeWinAnsi(text.text), st.face(), st.size)
+			}
+			l.y += st.leading()
+		}
+		l.contents = append(l.contents, line)
+	}
+	l.y = min(l.y+st.size*paragraphSpacing, l.bottom())
+}
+
+// contentsHeight returns the height of a table of contents.
+func (l *layout) contentsHeight(toc domain.TableOfContents) float64 {
+	titleStyle, st := contentsStyles(toc)
+	total := st.size * paragraphSpacing
+	if toc.Title != "" {
+		lines := textLines(toc.Title, titleStyle, l.contentWidth())
+		total += float64(len(lines))*titleStyle.leading() + titleStyle.size*(headingBefore+headingAfter)
+	}
+	for _, e := range l.contentsEntries(toc.Depth) {
+		_, _, lines := l.contentsLayout(e, st)
+		total += float64(len(lines)) * st.leading()
+	}
+	return total
+}
+
+// drawContents completes the table of contents entries with their page
+// numbers, dotted leaders and links. Entries whose target was not drawn are
+// left without.
+func (l *layout) drawContents() {
+	if len(l.contents) == 0 {
+		return
+	}
+	pageNumbers := make(map[pdf.Ref]int, len(l.pages))
+	for i, p := range l.pages {
+		pageNumbers[p.ref] = i + 1
+	}
+	right := l.left() + l.contentWidth()
+	for _, line := range l.contents {
+		var dest pdf.Array
+		if e := line.entry; e.heading >= 0 && e.heading < len(l.headings) {
+			dest = l.headings[e.heading]
+		} else if d, ok := l.anchors[pdf.Name(e.anchor)].(pdf.Array); ok && e.heading < 0 {
+			dest = d
+		}
+		if dest == nil {
+			continue
+		}
+		st := line.st
+		c := &line.page.canvas
+		baseline := l.pdfY(line.y + st.size*0.86)
+
+		number := encodeWinAnsi(strconv.Itoa(pageNumbers[dest[0].(pdf.Ref)]))
+		numberX := right - textWidth(number, st.face(), st.size)
+		c.text(numberX, baseline, st.face(), st.size, number)
+
+		dot := textWidth([]byte("."), st.face(), st.size)
+		leaderEnd := numberX - contentsLeaderGap
+		if n := int((leaderEnd - line.end - contentsLeaderGap) / dot); n > 0 {
+			leader := encodeWinAnsi(strings.Repeat(".", n))
+			c.text(leaderEnd-float64(n)*dot, baseline, st.face(), st.size, leader)
+		}
+
+		rect := pdf.Array{line.x, l.pdfY(line.y + st.leading()), right, l.pdfY(line.top)}
+		l.addLinkAnnot(line.page, rect, "Dest", dest)
+	}
+}
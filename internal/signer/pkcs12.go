@@ -0,0 +1,232 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// PKCS#12 and PKCS#7 content and bag types.
+var (
+	oidData                = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidEncryptedData       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidKeyBag              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 1}
+	oidShroudedKeyBag      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidX509Certificate     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidAttributeLocalKeyID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+)
+
+type pfx struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData `asn1:"optional"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo encryptedContentInfo
+}
+
+type encryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue  `asn1:"tag:0,explicit"`
+	Attributes []bagAttribute `asn1:"set,optional"`
+}
+
+type bagAttribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+// decodePKCS12 returns the private key and certificates of a PKCS#12 file.
+// The certificate whose local key ID matches the key comes first.
+func decodePKCS12(data []byte, password string) (crypto.PrivateKey, []*x509.Certificate, error) {
+	var p pfx
+	if rest, err := asn1.Unmarshal(data, &p); err != nil {
+		return nil, nil, fmt.Errorf("not a DER-encoded PKCS#12 file: %w", err)
+	} else if len(rest) > 0 {
+		return nil, nil, errors.New("trailing data after PKCS#12 content")
+	}
+	if p.Version != 3 {
+		return nil, nil, fmt.Errorf("unsupported PKCS#12 version %d", p.Version)
+	}
+	if !p.AuthSafe.ContentType.Equal(oidData) {
+		return nil, nil, errors.New("only password-integrity PKCS#12 files are supported")
+	}
+	var authSafe []byte
+	if _, err := asn1.Unmarshal(p.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		return nil, nil, fmt.Errorf("invalid PKCS#12 content: %w", err)
+	}
+	if len(p.MacData.Mac.Digest) > 0 {
+		if err := verifyMac(p.MacData, authSafe, password); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var contents []contentInfo
+	if _, err := asn1.Unmarshal(authSafe, &contents); err != nil {
+		return nil, nil, fmt.Errorf("invalid PKCS#12 content: %w", err)
+	}
+	var (
+		key    crypto.PrivateKey
+		keyID  []byte
+		certs  []*x509.Certificate
+		certID = make(map[*x509.Certificate][]byte)
+	)
+	for _, ci := range contents {
+		var bagsDER []byte
+		switch {
+		case ci.ContentType.Equal(oidData):
+			if _, err := asn1.Unmarshal(ci.Content.Bytes, &bagsDER); err != nil {
+				return nil, nil, fmt.Errorf("invalid PKCS#12 safe contents: %w", err)
+			}
+		case ci.ContentType.Equal(oidEncryptedData):
+			var ed encryptedData
+			if _, err := asn1.Unmarshal(ci.Content.Bytes, &ed); err != nil {
+				return nil, nil, fmt.Errorf("invalid PKCS#12 encrypted data: %w", err)
+			}
+			var err error
+			eci := ed.EncryptedContentInfo
+			if bagsDER, err = decryptPBE(eci.ContentEncryptionAlgorithm, eci.EncryptedContent, password); err != nil {
+				return nil, nil, err
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported PKCS#12 content type %v", ci.ContentType)
+		}
+
+		var bags []safeBag
+		if _, err := asn1.Unmarshal(bagsDER, &bags); err != nil {
+			return nil, nil, fmt.Errorf("invalid PKCS#12 safe contents: %w", err)
+		}
+		for _, bag := range bags {
+			switch {
+			case bag.ID.Equal(oidKeyBag), bag.ID.Equal(oidShroudedKeyBag):
+				der := bag.Value.Bytes
+				if bag.ID.Equal(oidShroudedKeyBag) {
+					var epki encryptedPrivateKeyInfo
+					if _, err := asn1.Unmarshal(der, &epki); err != nil {
+						return nil, nil, fmt.Errorf("invalid PKCS#12 key bag: %w", err)
+					}
+					var err error
+					if der, err = decryptPBE(epki.Algorithm, epki.EncryptedData, password); err != nil {
+						return nil, nil, err
+					}
+				}
+				k, err := x509.ParsePKCS8PrivateKey(der)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid PKCS#12 private key: %w", err)
+				}
+				if key != nil {
+					return nil, nil, errors.New("PKCS#12 file holds more than one private key")
+				}
+				key, keyID = k, localKeyID(bag)
+			case bag.ID.Equal(oidCertBag):
+				var cb certBag
+				if _, err := asn1.Unmarshal(bag.Value.Bytes, &cb); err != nil {
+					return nil, nil, fmt.Errorf("invalid PKCS#12 certificate bag: %w", err)
+				}
+				if !cb.ID.Equal(oidX509Certificate) {
+					continue
+				}
+				cert, err := x509.ParseCertificate(cb.Data)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid PKCS#12 certificate: %w", err)
+				}
+				certs = append(certs, cert)
+				certID[cert] = localKeyID(bag)
+			}
+		}
+	}
+	if key == nil {
+		return nil, nil, errors.New("PKCS#12 file holds no private key")
+	}
+	for i, cert := range certs {
+		if keyID != nil && string(certID[cert]) == string(keyID) {
+			certs[0], certs[i] = certs[i], certs[0]
+			break
+		}
+	}
+	return key, certs, nil
+}
+
+// localKeyID returns the local key ID attribute of a bag, which pairs a key
+// with its certificate.
+func localKeyID(bag safeBag) []byte {
+	for _, attr := range bag.Attributes {
+		if attr.ID.Equal(oidAttributeLocalKeyID) {
+			var id []byte
+			if _, err := asn1.Unmarshal(attr.Value.Bytes, &id); err == nil {
+				return id
+			}
+		}
+	}
+	return nil
+}
+
+// verifyMac checks the integrity of the authenticated safe, which also
+// tells whether the password is right.
+func verifyMac(m macData, content []byte, password string) error {
+	var h func() hash.Hash
+	v := 64
+	switch alg := m.Mac.Algorithm.Algorithm; {
+	case alg.Equal(oidSHA1):
+		h = sha1.New
+	case alg.Equal(oidSHA256):
+		h = sha256.New
+	case alg.Equal(oidSHA384):
+		h, v = sha512.New384, 128
+	case alg.Equal(oidSHA512):
+		h, v = sha512.New, 128
+	default:
+		return fmt.Errorf("unsupported PKCS#12 MAC algorithm %v", alg)
+	}
+	key := pkcs12KDF(h, v, bmpPassword(password), m.MacSalt, m.Iterations, 3, h().Size())
+	mac := hmac.New(h, key)
+	mac.Write(content)
+	if !hmac.Equal(mac.Sum(nil), m.Mac.Digest) {
+		return errors.New("PKCS#12 integrity check failed, the password may be wrong")
+	}
+	return nil
+}
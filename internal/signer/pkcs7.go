@@ -0,0 +1,280 @@
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Digest, signature and attribute algorithm identifiers.
+var (
+	oidSHA1              = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256            = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384            = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512            = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidRSAEncryption     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256   = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidAttrContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttrMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttrSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidAttrSigningCertV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+)
+
+// digestAlgorithms maps the digest algorithms accepted on verification.
+var digestAlgorithms = map[string]crypto.Hash{
+	oidSHA1.String():   crypto.SHA1,
+	oidSHA256.String(): crypto.SHA256,
+	oidSHA384.String(): crypto.SHA384,
+	oidSHA512.String(): crypto.SHA512,
+}
+
+// asn1SetTag is the tag signed attributes carry when they are signed, in
+// place of the [0] IMPLICIT tag they are embedded with.
+const asn1SetTag byte = 0x31
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerial struct {
+	IssuerName   asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// essCertIDv2 and signingCertificateV2 bind the signing certificate to the
+// signature (RFC 5035).
+type essCertIDv2 struct {
+	CertHash []byte
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+// signPKCS7 creates a detached PKCS#7 SignedData over content with SHA-256.
+func signPKCS7(content []byte, key crypto.Signer, chain []*x509.Certificate, signingTime time.Time) ([]byte, error) {
+	cert := chain[0]
+	digest := crypto.SHA256.New()
+	digest.Write(content)
+	certHash := crypto.SHA256.New()
+	certHash.Write(cert.Raw)
+
+	attrs, err := encodeAttributes(
+		attr(oidAttrContentType, oidData),
+		attr(oidAttrSigningTime, signingTime.UTC()),
+		attr(oidAttrMessageDigest, digest.Sum(nil)),
+		attr(oidAttrSigningCertV2, signingCertificateV2{Certs: []essCertIDv2{{CertHash: certHash.Sum(nil)}}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature covers the attributes encoded as a SET OF.
+	toSign := append([]byte{asn1SetTag}, attrs[1:]...)
+	h := crypto.SHA256.New()
+	h.Write(toSign)
+
+	var sigAlg asn1.ObjectIdentifier
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		sigAlg = oidRSAEncryption
+	case *ecdsa.PublicKey:
+		sigAlg = oidECDSAWithSHA256
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.Public())
+	}
+	signature, err := key.Sign(rand.Reader, h.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []byte
+	for _, c := range chain {
+		certs = append(certs, c.Raw...)
+	}
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidSHA256}},
+		ContentInfo:      contentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certs},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			IssuerAndSerialNumber: issuerAndSerial{
+				IssuerName:   asn1.RawValue{FullBytes: cert.RawIssuer},
+				SerialNumber: cert.SerialNumber,
+			},
+			DigestAlgorithm:           pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			AuthenticatedAttributes:   asn1.RawValue{FullBytes: attrs},
+			DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: sigAlg},
+			EncryptedDigest:           signature,
+		}},
+	}
+	inner, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: inner},
+	})
+}
+
+// attrValue is an attribute before encoding.
+type attrValue struct {
+	oid   asn1.ObjectIdentifier
+	value interface{}
+}
+
+func attr(oid asn1.ObjectIdentifier, value interface{}) attrValue {
+	return attrValue{oid: oid, value: value}
+}
+
+// encodeAttributes encodes signed attributes as the [0] IMPLICIT SET OF
+// of a SignerInfo, sorted as DER requires.
+func encodeAttributes(values ...attrValue) ([]byte, error) {
+	encoded := make([][]byte, len(values))
+	for i, v := range values {
+		der, err := asn1.Marshal(v.value)
+		if err != nil {
+			return nil, err
+		}
+		if encoded[i], err = asn1.Marshal(attribute{Type: v.oid, Values: []asn1.RawValue{{FullBytes: der}}}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      bytes.Join(encoded, nil),
+	})
+}
+
+// verifiedPKCS7 is the result of verifying a detached SignedData.
+type verifiedPKCS7 struct {
+	cert        *x509.Certificate
+	signingTime time.Time
+}
+
+// verifyPKCS7 checks a detached SignedData against content: the message
+// digest attribute must match the content and the signature must verify
+// with the signer certificate. The certificate chain is not validated.
+func verifyPKCS7(der []byte, content []byte) (*verifiedPKCS7, error) {
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, fmt.Errorf("invalid PKCS#7 data: %w", err)
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, errors.New("PKCS#7 data is not SignedData")
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("invalid SignedData: %w", err)
+	}
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificates: %w", err)
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expected one signer, found %d", len(sd.SignerInfos))
+	}
+	si := sd.SignerInfos[0]
+
+	var cert *x509.Certificate
+	for _, c := range certs {
+		if bytes.Equal(c.RawIssuer, si.IssuerAndSerialNumber.IssuerName.FullBytes) &&
+			c.SerialNumber.Cmp(si.IssuerAndSerialNumber.SerialNumber) == 0 {
+			cert = c
+			break
+		}
+	}
+	if cert == nil {
+		return nil, errors.New("signer certificate not found")
+	}
+
+	hashAlg, ok := digestAlgorithms[si.DigestAlgorithm.Algorithm.String()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported digest algorithm %v", si.DigestAlgorithm.Algorithm)
+	}
+	h := hashAlg.New()
+	h.Write(content)
+	digest := h.Sum(nil)
+
+	result := &verifiedPKCS7{cert: cert}
+	if len(si.AuthenticatedAttributes.FullBytes) > 0 {
+		var attrs []attribute
+		if _, err := asn1.UnmarshalWithParams(si.AuthenticatedAttributes.FullBytes, &attrs, "set,tag:0"); err != nil {
+			return nil, fmt.Errorf("invalid signed attributes: %w", err)
+		}
+		var messageDigest []byte
+		for _, a := range attrs {
+			if len(a.Values) == 0 {
+				continue
+			}
+			switch {
+			case a.Type.Equal(oidAttrMessageDigest):
+				if _, err := asn1.Unmarshal(a.Values[0].FullBytes, &messageDigest); err != nil {
+					return nil, fmt.Errorf("invalid message digest: %w", err)
+				}
+			case a.Type.Equal(oidAttrSigningTime):
+				var t time.Time
+				if _, err := asn1.Unmarshal(a.Values[0].FullBytes, &t); err == nil {
+					result.signingTime = t
+				}
+			}
+		}
+		if !bytes.Equal(messageDigest, digest) {
+			return nil, errors.New("message digest does not match the signed content")
+		}
+		h = hashAlg.New()
+		h.Write([]byte{asn1SetTag})
+		h.Write(si.AuthenticatedAttributes.FullBytes[1:])
+		digest = h.Sum(nil)
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(pub, hashAlg, digest, si.EncryptedDigest)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, si.EncryptedDigest) {
+			err = errors.New("ECDSA verification failed")
+		}
+	default:
+		err = fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signature does not verify: %w", err)
+	}
+	return result, nil
+}
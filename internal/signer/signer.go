@@ -0,0 +1,551 @@
+// Package signer applies and verifies PDF digital signatures. Signatures are
+// detached PKCS#7 (CMS) messages stored in an incremental update, so the
+// signed bytes of the original file are left untouched. Only the standard
+// library is used.
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// byteRangePlaceholder reserves room for the byte range, which is only known
+// once the signed file is laid out.
+const byteRangePlaceholder = "[0 0000000000 0000000000 0000000000]"
+
+// contentsMargin is added to the estimated signature size, which varies by a
+// few bytes between signatures with ECDSA keys.
+const contentsMargin = 64
+
+// Signer signs PDFs with a private key and its certificate chain.
+type Signer struct {
+	key   crypto.Signer
+	chain []*x509.Certificate
+	now   func() time.Time
+}
+
+var _ domain.PDFSigner = (*Signer)(nil)
+
+// New creates a Signer from a private key, its certificate and optional
+// intermediate certificates, which are embedded in each signature. RSA and
+// ECDSA keys are supported.
+func New(key crypto.PrivateKey, cert *x509.Certificate, chain ...*x509.Certificate) (*Signer, error) {
+	if cert == nil {
+		return nil, fmt.Errorf("%w: no certificate", domain.ErrInvalidKey)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T", domain.ErrInvalidKey, key)
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("%w: private key does not match the certificate", domain.ErrInvalidKey)
+	}
+	if _, err := signPKCS7(nil, signer, []*x509.Certificate{cert}, time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidKey, err)
+	}
+	return &Signer{
+		key:   signer,
+		chain: append([]*x509.Certificate{cert}, chain...),
+		now:   time.Now,
+	}, nil
+}
+
+// FromPKCS12 creates a Signer from a PKCS#12 (.p12 or .pfx) file holding a
+// private key and its certificate chain.
+func FromPKCS12(data []byte, password string) (*Signer, error) {
+	key, certs, err := decodePKCS12(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidKey, err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%w: PKCS#12 file holds no certificate", domain.ErrInvalidKey)
+	}
+	return New(key, certs[0], certs[1:]...)
+}
+
+// FromPEM creates a Signer from PEM-encoded certificates and a private key.
+// The first certificate must belong to the key; any others are embedded as
+// the chain. The key may be PKCS#8, PKCS#1 or SEC 1; password decrypts an
+// encrypted PKCS#8 key. Both arguments may hold the same bytes.
+func FromPEM(certPEM, keyPEM []byte, password string) (*Signer, error) {
+	var certs []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidKey, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%w: no PEM certificate found", domain.ErrInvalidKey)
+	}
+
+	var key crypto.PrivateKey
+	for rest := keyPEM; key == nil; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			return nil, fmt.Errorf("%w: no PEM private key found", domain.ErrInvalidKey)
+		}
+		var err error
+		if key, err = parsePEMKey(block, password); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidKey, err)
+		}
+	}
+	return New(key, certs[0], certs[1:]...)
+}
+
+// parsePEMKey parses a private key block, or returns nil for other blocks.
+func parsePEMKey(block *pem.Block, password string) (crypto.PrivateKey, error) {
+	if _, ok := block.Headers["DEK-Info"]; ok {
+		return nil, errors.New("legacy encrypted PEM keys are not supported, use PKCS#8")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		var epki encryptedPrivateKeyInfo
+		if _, err := asn1.Unmarshal(block.Bytes, &epki); err != nil {
+			return nil, fmt.Errorf("invalid encrypted private key: %w", err)
+		}
+		der, err := decryptPBE(epki.Algorithm, epki.EncryptedData, password)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key, the password may be wrong: %w", err)
+		}
+		return key, nil
+	}
+	return nil, nil
+}
+
+// Certificate returns the signing certificate.
+func (s *Signer) Certificate() *x509.Certificate {
+	return s.chain[0]
+}
+
+// Sign signs a PDF and returns the signed file. When sig names a field, the
+// signature is placed in that unsigned signature field with an appearance
+// showing the signer, date, reason and location; otherwise an invisible
+// signature field is added to the first page.
+func (s *Signer) Sign(data []byte, sig domain.Signature) ([]byte, error) {
+	r, err := pdf.NewReader(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+	trailer := r.Trailer()
+	if trailer["Encrypt"] != nil {
+		return nil, fmt.Errorf("%w: signing encrypted PDFs", domain.ErrUnsupported)
+	}
+	catalogRef, _ := trailer["Root"].(pdf.Ref)
+	catalog, err := r.ResolveDict(catalogRef)
+	if err != nil || catalog == nil {
+		return nil, fmt.Errorf("%w: missing document catalog", domain.ErrInvalidPDF)
+	}
+	catalog = clone(catalog)
+
+	now := s.now()
+	if sig.Name == "" {
+		sig.Name = s.Certificate().Subject.CommonName
+	}
+
+	u := r.NewUpdate()
+	formRef, _ := catalog["AcroForm"].(pdf.Ref)
+	form, err := r.ResolveDict(catalog["AcroForm"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+	form = clone(form)
+	if form == nil {
+		form = pdf.Dict{}
+	}
+	fields, err := collectFields(r, form)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+
+	sigRef := u.Alloc()
+	var fieldRef pdf.Ref
+	if sig.Field != "" {
+		f, ok := findField(fields, sig.Field)
+		if !ok {
+			return nil, fmt.Errorf("%w: no signature field named %q", domain.ErrInvalidConfig, sig.Field)
+		}
+		if f.fieldType != "Sig" {
+			return nil, fmt.Errorf("%w: field %q is not a signature field", domain.ErrInvalidConfig, sig.Field)
+		}
+		if f.dict["V"] != nil {
+			return nil, fmt.Errorf("%w: signature field %q is already signed", domain.ErrInvalidConfig, sig.Field)
+		}
+		fieldRef = f.ref
+		field := clone(f.dict)
+		field["V"] = sigRef
+		widgetRef, widget := fieldRef, field
+		if kids, _ := r.ResolveArray(field["Kids"]); len(kids) > 0 {
+			widgetRef, _ = kids[0].(pdf.Ref)
+			kid, err := r.ResolveDict(widgetRef)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+			}
+			widget = clone(kid)
+		}
+		if ap := appearance(u, r, widget["Rect"], sig, now); ap != nil {
+			widget["AP"] = pdf.Dict{"N": *ap}
+			if widgetRef != fieldRef {
+				u.Set(widgetRef, widget)
+			}
+		}
+		u.Set(fieldRef, field)
+	} else {
+		pageRef, page, err := firstPage(r, catalog)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+		}
+		fieldRef = u.Alloc()
+		u.Set(fieldRef, pdf.Dict{
+			"Type":    pdf.Name("Annot"),
+			"Subtype": pdf.Name("Widget"),
+			"FT":      pdf.Name("Sig"),
+			"T":       pdf.String(uniqueName(fields)),
+			"Rect":    pdf.Array{0, 0, 0, 0},
+			"F":       132, // Hidden | Locked
+			"P":       pageRef,
+			"V":       sigRef,
+		})
+		if err := appendRef(u, r, page, "Annots", fieldRef); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+		}
+		u.Set(pageRef, page)
+		if err := appendRef(u, r, form, "Fields", fieldRef); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+		}
+	}
+
+	form["SigFlags"] = 3 // SignaturesExist | AppendOnly
+	if formRef != (pdf.Ref{}) {
+		u.Set(formRef, form)
+	} else {
+		catalog["AcroForm"] = form
+		u.Set(catalogRef, catalog)
+	}
+
+	estimate, err := signPKCS7(nil, s.key, s.chain, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign PDF: %w", err)
+	}
+	sigDict := pdf.Dict{
+		"Type":      pdf.Name("Sig"),
+		"Filter":    pdf.Name("Adobe.PPKLite"),
+		"SubFilter": pdf.Name("adbe.pkcs7.detached"),
+		"ByteRange": pdf.Raw(byteRangePlaceholder),
+		"Contents":  pdf.HexString(make([]byte, len(estimate)+contentsMargin)),
+		"M":         pdf.Date(now),
+		"Name":      pdf.TextString(sig.Name),
+	}
+	for key, value := range map[pdf.Name]string{
+		"Reason":      sig.Reason,
+		"Location":    sig.Location,
+		"ContactInfo": sig.ContactInfo,
+	} {
+		if value != "" {
+			sigDict[key] = pdf.TextString(value)
+		}
+	}
+	u.Set(sigRef, sigDict)
+
+	next := pdf.Dict{"Root": catalogRef}
+	for _, key := range []pdf.Name{"Info", "ID"} {
+		if v, ok := trailer[key]; ok {
+			next[key] = v
+		}
+	}
+	out := u.Bytes(next)
+	return s.fill(out, len(data), now)
+}
+
+// fill computes the byte range of the update written after offset, signs it
+// and writes the range and signature into their placeholders.
+func (s *Signer) fill(out []byte, offset int, now time.Time) ([]byte, error) {
+	rangeAt := bytes.Index(out[offset:], []byte(byteRangePlaceholder))
+	if rangeAt < 0 {
+		return nil, errors.New("failed to sign PDF: byte range placeholder not found")
+	}
+	rangeAt += offset
+	contentsAt := bytes.Index(out[rangeAt:], []byte("/Contents <"))
+	if contentsAt < 0 {
+		return nil, errors.New("failed to sign PDF: contents placeholder not found")
+	}
+	start := rangeAt + contentsAt + len("/Contents ")
+	end := start + bytes.IndexByte(out[start:], '>') + 1
+
+	byteRange := fmt.Sprintf("[0 %010d %010d %010d]", start, end, len(out)-end)
+	copy(out[rangeAt:], byteRange)
+
+	content := make([]byte, 0, len(out)-(end-start))
+	content = append(content, out[:start]...)
+	content = append(content, out[end:]...)
+	der, err := signPKCS7(content, s.key, s.chain, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign PDF: %w", err)
+	}
+	if 2*len(der) > end-start-2 {
+		return nil, errors.New("failed to sign PDF: signature larger than its placeholder")
+	}
+	copy(out[start+1:], strings.ToUpper(hex.EncodeToString(der)))
+	return out, nil
+}
+
+// formField is a terminal form field with its fully qualified name and
+// inherited field type.
+type formField struct {
+	ref       pdf.Ref
+	dict      pdf.Dict
+	name      string
+	fieldType pdf.Name
+}
+
+// collectFields walks the field tree of an AcroForm dictionary.
+func collectFields(r *pdf.Reader, form pdf.Dict) ([]formField, error) {
+	var fields []formField
+	var walk func(refs pdf.Array, prefix string, fieldType pdf.Name, depth int) error
+	walk = func(refs pdf.Array, prefix string, fieldType pdf.Name, depth int) error {
+		if depth > 32 {
+			return errors.New("form field tree too deep")
+		}
+		for _, item := range refs {
+			ref, _ := item.(pdf.Ref)
+			dict, err := r.ResolveDict(item)
+			if err != nil {
+				return err
+			}
+			if dict == nil {
+				continue
+			}
+			name := prefix
+			if t, ok := dict["T"].(pdf.String); ok {
+				if name != "" {
+					name += "."
+				}
+				name += pdf.DecodeText(t)
+			}
+			ft := fieldType
+			if t, ok := dict["FT"].(pdf.Name); ok {
+				ft = t
+			}
+			kids, err := r.ResolveArray(dict["Kids"])
+			if err != nil {
+				return err
+			}
+			// Kids without names are the widgets of this field.
+			if len(kids) > 0 && hasNamedKid(r, kids) {
+				if err := walk(kids, name, ft, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			fields = append(fields, formField{ref: ref, dict: dict, name: name, fieldType: ft})
+		}
+		return nil
+	}
+	refs, err := r.ResolveArray(form["Fields"])
+	if err != nil {
+		return nil, err
+	}
+	return fields, walk(refs, "", "", 0)
+}
+
+func hasNamedKid(r *pdf.Reader, kids pdf.Array) bool {
+	for _, kid := range kids {
+		if dict, _ := r.ResolveDict(kid); dict != nil && dict["T"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func findField(fields []formField, name string) (formField, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return formField{}, false
+}
+
+// uniqueName returns a field name for an invisible signature that is not
+// taken yet.
+func uniqueName(fields []formField) string {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("Signature%d", i)
+		if _, taken := findField(fields, name); !taken {
+			return name
+		}
+	}
+}
+
+// firstPage returns a copy of the first page dictionary.
+func firstPage(r *pdf.Reader, catalog pdf.Dict) (pdf.Ref, pdf.Dict, error) {
+	node := catalog["Pages"]
+	for depth := 0; depth < 32; depth++ {
+		ref, _ := node.(pdf.Ref)
+		dict, err := r.ResolveDict(node)
+		if err != nil {
+			return ref, nil, err
+		}
+		if dict == nil {
+			break
+		}
+		if dict["Type"] == pdf.Name("Page") {
+			if ref == (pdf.Ref{}) {
+				break
+			}
+			return ref, clone(dict), nil
+		}
+		kids, err := r.ResolveArray(dict["Kids"])
+		if err != nil {
+			return ref, nil, err
+		}
+		if len(kids) == 0 {
+			break
+		}
+		node = kids[0]
+	}
+	return pdf.Ref{}, nil, errors.New("document has no pages")
+}
+
+// appendRef appends ref to the array under key, updating the array object
+// itself when dict refers to it indirectly.
+func appendRef(u *pdf.Update, r *pdf.Reader, dict pdf.Dict, key pdf.Name, ref pdf.Ref) error {
+	items, err := r.ResolveArray(dict[key])
+	if err != nil {
+		return err
+	}
+	items = append(append(pdf.Array{}, items...), ref)
+	if arrayRef, ok := dict[key].(pdf.Ref); ok {
+		u.Set(arrayRef, items)
+	} else {
+		dict[key] = items
+	}
+	return nil
+}
+
+// appearance adds the appearance stream of a visible signature and returns
+// its reference, or nil when the widget has no area.
+func appearance(u *pdf.Update, r *pdf.Reader, rect pdf.Object, sig domain.Signature, now time.Time) *pdf.Ref {
+	box, _ := r.ResolveArray(rect)
+	if len(box) != 4 {
+		return nil
+	}
+	var v [4]float64
+	for i, item := range box {
+		switch n := item.(type) {
+		case int:
+			v[i] = float64(n)
+		case float64:
+			v[i] = n
+		}
+	}
+	w, h := v[2]-v[0], v[3]-v[1]
+	if w < 0 {
+		w = -w
+	}
+	if h < 0 {
+		h = -h
+	}
+	if w == 0 || h == 0 {
+		return nil
+	}
+
+	lines := []string{
+		"Digitally signed by " + sig.Name,
+		"Date: " + now.Format("2006-01-02 15:04:05 -07:00"),
+	}
+	if sig.Reason != "" {
+		lines = append(lines, "Reason: "+sig.Reason)
+	}
+	if sig.Location != "" {
+		lines = append(lines, "Location: "+sig.Location)
+	}
+
+	// Helvetica averages about half an em per character, which is close
+	// enough to keep the lines inside the box.
+	longest := 1
+	for _, line := range lines {
+		longest = max(longest, len([]rune(line)))
+	}
+	size := min(10, (h-4)/(float64(len(lines))*1.2), (w-4)/(float64(longest)*0.5))
+	size = max(size, 1)
+	leading := size * 1.2
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT /F1 %s Tf %s TL 2 %s Td\n",
+		pdf.FormatNumber(size), pdf.FormatNumber(leading), pdf.FormatNumber(h-2-size))
+	for i, line := range lines {
+		if i > 0 {
+			content.WriteString("T* ")
+		}
+		fmt.Fprintf(&content, "%s Tj\n", pdf.Literal(latin1(line)))
+	}
+	content.WriteString("ET")
+
+	font := u.Add(pdf.Dict{
+		"Type":     pdf.Name("Font"),
+		"Subtype":  pdf.Name("Type1"),
+		"BaseFont": pdf.Name("Helvetica"),
+		"Encoding": pdf.Name("WinAnsiEncoding"),
+	})
+	ref := u.Add(pdf.FlateStream(pdf.Dict{
+		"Type":      pdf.Name("XObject"),
+		"Subtype":   pdf.Name("Form"),
+		"BBox":      pdf.Array{0.0, 0.0, w, h},
+		"Resources": pdf.Dict{"Font": pdf.Dict{"F1": font}},
+	}, content.Bytes()))
+	return &ref
+}
+
+// latin1 encodes s for a WinAnsi font, replacing characters outside Latin-1.
+func latin1(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xff {
+			r = '?'
+		}
+		out = append(out, byte(r))
+	}
+	return out
+}
+
+func clone(d pdf.Dict) pdf.Dict {
+	if d == nil {
+		return nil
+	}
+	out := make(pdf.Dict, len(d))
+	for k, v := range d {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,265 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/builder"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/renderer"
+)
+
+const props = "font1:10:000:left:1:1:1:1"
+
+// testSigner creates a Signer with a fresh key and self-signed certificate.
+func testSigner(t *testing.T, key crypto.Signer, name string) *Signer {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(key, cert)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+// testPDF renders a one-table document with an empty signature field named
+// "approval".
+func testPDF(t *testing.T) []byte {
+	t.Helper()
+	doc := builder.NewDocumentBuilder().
+		AddTable(builder.NewTableBuilder().
+			WithColumns(2, []float64{1, 1}).
+			AddRowWithHeight(60, builder.Cell(props, "Approved by"), builder.SignatureCell(props, "approval")).
+			Build()).
+		Build()
+	out, err := renderer.New().Send(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return out
+}
+
+func TestSignVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		key       crypto.Signer
+		sig       domain.Signature
+		wantField string
+		wantName  string // the certificate CN when the signature has no name
+	}{
+		{"RSA invisible", rsaKey, domain.Signature{Name: "Jane Signer", Reason: "Approval"}, "Signature1", "Jane Signer"},
+		{"ECDSA invisible", ecKey, domain.Signature{Location: "Berlin", ContactInfo: "jane@example.com"}, "Signature1", "ECDSA invisible"},
+		{"RSA in field", rsaKey, domain.Signature{Field: "approval", Name: "Jane Signer", Reason: "Approval"}, "approval", "Jane Signer"},
+		{"ECDSA in field", ecKey, domain.Signature{Field: "approval", Location: "Zürich"}, "approval", "ECDSA in field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSigner(t, tt.key, tt.name)
+			s.now = func() time.Time { return signingTime }
+			unsigned := testPDF(t)
+
+			signed, err := s.Sign(unsigned, tt.sig)
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			if !bytes.HasPrefix(signed, unsigned) {
+				t.Fatal("signing rewrote the original bytes instead of appending an update")
+			}
+
+			infos, err := Verify(signed)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if len(infos) != 1 {
+				t.Fatalf("got %d signatures, want 1", len(infos))
+			}
+			info := infos[0]
+			if info.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", info.Field, tt.wantField)
+			}
+			if info.Name != tt.wantName || info.Reason != tt.sig.Reason ||
+				info.Location != tt.sig.Location || info.ContactInfo != tt.sig.ContactInfo {
+				t.Errorf("info = %+v, want the details of %+v", info, tt.sig)
+			}
+			if !info.SigningTime.Equal(signingTime) {
+				t.Errorf("SigningTime = %v, want %v", info.SigningTime, signingTime)
+			}
+			if !info.Certificate.Equal(s.Certificate()) {
+				t.Error("Certificate is not the signing certificate")
+			}
+			if !info.CoversWholeFile {
+				t.Error("CoversWholeFile = false for an unmodified file")
+			}
+		})
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := testSigner(t, key, "Tamper Test").Sign(testPDF(t), domain.Signature{Name: "Jane Signer"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	contents := bytes.LastIndex(signed, []byte("/Contents <")) + len("/Contents <")
+
+	tests := []struct {
+		name   string
+		tamper func([]byte) []byte
+	}{
+		// The second header line is a comment, so the file still parses.
+		{"signed bytes changed", func(b []byte) []byte { b[10] ^= 0x01; return b }},
+		{"signature changed", func(b []byte) []byte {
+			if b[contents+20] == '0' {
+				b[contents+20] = '1'
+			} else {
+				b[contents+20] = '0'
+			}
+			return b
+		}},
+		{"signature removed", func(b []byte) []byte {
+			copy(b[contents:], bytes.Repeat([]byte("0"), bytes.IndexByte(b[contents:], '>')))
+			return b
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.tamper(append([]byte{}, signed...))
+			if _, err := Verify(data); !errors.Is(err, domain.ErrInvalidSignature) {
+				t.Fatalf("Verify error = %v, want %v", err, domain.ErrInvalidSignature)
+			}
+		})
+	}
+
+	t.Run("bytes appended", func(t *testing.T) {
+		infos, err := Verify(append(append([]byte{}, signed...), "\n% appended\n"...))
+		if err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+		if infos[0].CoversWholeFile {
+			t.Error("CoversWholeFile = true after bytes were appended")
+		}
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		if _, err := Verify(testPDF(t)); !errors.Is(err, domain.ErrInvalidSignature) {
+			t.Fatalf("Verify error = %v, want %v", err, domain.ErrInvalidSignature)
+		}
+	})
+}
+
+func TestSignTwice(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testSigner(t, key, "Twice")
+	once, err := s.Sign(testPDF(t), domain.Signature{Field: "approval", Name: "First"})
+	if err != nil {
+		t.Fatalf("first Sign: %v", err)
+	}
+	twice, err := s.Sign(once, domain.Signature{Name: "Second"})
+	if err != nil {
+		t.Fatalf("second Sign: %v", err)
+	}
+
+	infos, err := Verify(twice)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("got %d signatures, want 2", len(infos))
+	}
+	for _, info := range infos {
+		if want := info.Name == "Second"; info.CoversWholeFile != want {
+			t.Errorf("signature %q: CoversWholeFile = %v, want %v", info.Name, info.CoversWholeFile, want)
+		}
+	}
+
+	if _, err := s.Sign(once, domain.Signature{Field: "approval"}); err == nil {
+		t.Error("signing an already signed field succeeded")
+	}
+}
+
+func TestFromPKCS12(t *testing.T) {
+	// The files were made with OpenSSL 3: AES-256 with PBKDF2 is its default,
+	// RC2 and 3DES are the -legacy and -certpbe/-keypbe PBE-SHA1-3DES forms.
+	tests := []struct {
+		file     string
+		password string
+		wantCN   string
+		wantErr  bool
+	}{
+		{"rsa-aes.p12", "secret", "Jane Signer", false},
+		{"rsa-rc2.p12", "secret", "Jane Signer", false},
+		{"rsa-3des.p12", "secret", "Jane Signer", false},
+		{"ec-aes.p12", "pässwörd", "EC Signer", false},
+		{"rsa-aes.p12", "wrong", "", true},
+		{"rsa-rc2.p12", "wrong", "", true},
+		{"rsa-3des.p12", "", "", true},
+		{"ec-aes.p12", "passwoerd", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+"/"+tt.password, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := FromPKCS12(data, tt.password)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidKey) {
+					t.Fatalf("FromPKCS12 error = %v, want %v", err, domain.ErrInvalidKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromPKCS12: %v", err)
+			}
+			if cn := s.Certificate().Subject.CommonName; cn != tt.wantCN {
+				t.Errorf("certificate CN = %q, want %q", cn, tt.wantCN)
+			}
+			if _, err := s.Sign(testPDF(t), domain.Signature{}); err != nil {
+				t.Errorf("Sign: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/domain"
+	"github.com/chinmay-sawant/gopdfsuit-client/internal/pdf"
+)
+
+// Verify checks every signature of a PDF and describes them. It fails with
+// ErrInvalidSignature when the file has no signatures or any signature does
+// not match the bytes it covers. The signing certificates are not checked
+// against trusted roots.
+func Verify(data []byte) ([]domain.SignatureInfo, error) {
+	r, err := pdf.NewReader(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+	catalog, err := r.ResolveDict(r.Trailer()["Root"])
+	if err != nil || catalog == nil {
+		return nil, fmt.Errorf("%w: missing document catalog", domain.ErrInvalidPDF)
+	}
+	form, err := r.ResolveDict(catalog["AcroForm"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+	fields, err := collectFields(r, form)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPDF, err)
+	}
+
+	var infos []domain.SignatureInfo
+	for _, f := range fields {
+		if f.fieldType != "Sig" || f.dict["V"] == nil {
+			continue
+		}
+		sig, err := r.ResolveDict(f.dict["V"])
+		if err != nil || sig == nil {
+			return nil, fmt.Errorf("%w: field %q: missing signature dictionary", domain.ErrInvalidSignature, f.name)
+		}
+		info, err := verifyField(data, sig)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q: %v", domain.ErrInvalidSignature, f.name, err)
+		}
+		info.Field = f.name
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: document has no signatures", domain.ErrInvalidSignature)
+	}
+	return infos, nil
+}
+
+// verifyField verifies the signature dictionary of one field.
+func verifyField(data []byte, sig pdf.Dict) (domain.SignatureInfo, error) {
+	var info domain.SignatureInfo
+	var contents []byte
+	switch v := sig["Contents"].(type) {
+	case pdf.HexString:
+		contents = v
+	case pdf.String:
+		contents = []byte(v)
+	default:
+		return info, errors.New("missing signature contents")
+	}
+
+	byteRange, _ := sig["ByteRange"].(pdf.Array)
+	var br [4]int
+	if len(byteRange) != 4 {
+		return info, errors.New("missing byte range")
+	}
+	for i, item := range byteRange {
+		n, ok := item.(int)
+		if !ok || n < 0 {
+			return info, errors.New("invalid byte range")
+		}
+		br[i] = n
+	}
+	// The range must start at the beginning of the file and skip exactly
+	// the hex string holding the signature.
+	if br[0] != 0 || br[1] >= br[2] || br[2]+br[3] > len(data) ||
+		data[br[1]] != '<' || data[br[2]-1] != '>' ||
+		bytes.ContainsAny(data[br[1]+1:br[2]-1], "<>") {
+		return info, errors.New("byte range does not exclude just the signature")
+	}
+
+	content := make([]byte, 0, br[1]+br[3])
+	content = append(content, data[:br[1]]...)
+	content = append(content, data[br[2]:br[2]+br[3]]...)
+	result, err := verifyPKCS7(contents, content)
+	if err != nil {
+		return info, err
+	}
+
+	text := func(key pdf.Name) string {
+		s, _ := sig[key].(pdf.String)
+		return pdf.DecodeText(s)
+	}
+	info.Name = text("Name")
+	info.Reason = text("Reason")
+	info.Location = text("Location")
+	info.ContactInfo = text("ContactInfo")
+	info.SigningTime = result.signingTime
+	info.Certificate = result.cert
+	info.CoversWholeFile = br[2]+br[3] == len(data)
+	return info, nil
+}